@@ -51,6 +51,10 @@ var (
 	ErrMalformedNumber = errors.New("malformed number")
 	// ErrMalformedNumberSlice occurs when the number slice is malformed.
 	ErrMalformedNumberSlice = errors.New("malformed number slice")
+	// ErrNumberOutOfRange occurs when the number overflows the range of the target type.
+	ErrNumberOutOfRange = errors.New("number is out of range")
+	// ErrNumberPrecisionLoss occurs when the number can't be represented by the target type exactly.
+	ErrNumberPrecisionLoss = errors.New("number loses precision")
 	// ErrBooleanNull occurs when the boolean value is nil.
 	ErrBooleanNull = errors.New("boolean value must not be null")
 	// ErrMalformedBoolean occurs when the boolean value is malformed.
@@ -59,8 +63,38 @@ var (
 	ErrMalformedBooleanSlice = errors.New("malformed boolean slice")
 	// ErrBooleanSliceNull occurs when the boolean slice is nil.
 	ErrBooleanSliceNull = errors.New("boolean slice must not be null")
+	// ErrTimeNull occurs when the time value is nil.
+	ErrTimeNull = errors.New("time value must not be null")
+	// ErrMalformedTime occurs when the time value is malformed.
+	ErrMalformedTime = errors.New("malformed time")
+	// ErrMalformedTimeSlice occurs when the time slice is malformed.
+	ErrMalformedTimeSlice = errors.New("malformed time slice")
+	// ErrDurationNull occurs when the duration value is nil.
+	ErrDurationNull = errors.New("duration value must not be null")
+	// ErrMalformedDuration occurs when the duration value is malformed.
+	ErrMalformedDuration = errors.New("malformed duration")
+	// ErrMalformedDurationSlice occurs when the duration slice is malformed.
+	ErrMalformedDurationSlice = errors.New("malformed duration slice")
+	// ErrURLNull occurs when the URL value is nil.
+	ErrURLNull = errors.New("url value must not be null")
+	// ErrMalformedURL occurs when the URL value is malformed.
+	ErrMalformedURL = errors.New("malformed url")
+	// ErrMalformedURLSlice occurs when the URL slice is malformed.
+	ErrMalformedURLSlice = errors.New("malformed url slice")
+	// ErrMalformedSlice occurs when the value isn't a slice or an array.
+	ErrMalformedSlice = errors.New("malformed slice")
+	// ErrMalformedMap occurs when the value isn't a map or an object.
+	ErrMalformedMap = errors.New("malformed map")
 	// ErrMalformedYAML occurs when the YAML syntax or structure is malformed.
 	ErrMalformedYAML = errors.New("malformed YAML")
+	// ErrDuplicateKey occurs when a JSON object or YAML mapping has the same key more than once.
+	ErrDuplicateKey = errors.New("duplicate key")
+	// ErrCircuitOpen occurs when the circuit breaker rejects a request because the downstream service is failing.
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+	// ErrBulkheadFull occurs when the bulkhead rejects a request because the host has too many in-flight requests.
+	ErrBulkheadFull = errors.New("too many in-flight requests to the host")
+	// ErrRateLimited occurs when the rate limiter rejects a request because the limit of its key is exceeded.
+	ErrRateLimited = errors.New("rate limit exceeded")
 )
 
 // CatchWarnErrorFunc catches the closer function and prints error with the WARN level.
@@ -15,10 +15,13 @@
 package goutils
 
 import (
+	"bufio"
+	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"os"
@@ -26,61 +29,92 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/relychan/goutils/httperror"
-	"go.yaml.in/yaml/v4"
+	"github.com/relychan/goutils/httpheader"
 )
 
 var (
 	errFilePathRequired             = errors.New("file path is required")
 	errDisallowedFilePath           = errors.New("file path is not allowed to read")
 	errFileNoContent                = errors.New("file has no content")
-	errUnsupportedFilePathExtension = errors.New("only {json,yaml,yml} extension is supported")
+	errUnsupportedFilePathExtension = errors.New("file extension is not supported")
+	errTooManyRedirects             = errors.New("too many redirects")
+	errDisallowedContentType        = errors.New("content type is not allowed")
 )
 
+// FileFormat represents the format of a document file.
+type FileFormat string
+
+const (
+	// FileFormatJSON represents the JSON format.
+	FileFormatJSON FileFormat = "json"
+	// FileFormatYAML represents the YAML format.
+	FileFormatYAML FileFormat = "yaml"
+)
+
+// sniffLength is the maximum number of bytes to detect the format from the content.
+const sniffLength = 512
+
+// defaultMaxRedirects is the default number of redirects that are followed,
+// which is the same as the default policy of [http.Client].
+const defaultMaxRedirects = 10
+
+// FileTooLargeError occurs when the file content exceeds the maximum number of bytes.
+type FileTooLargeError struct {
+	// The maximum number of bytes that are allowed to read.
+	Limit int64
+}
+
+// Error implements the error interface for FileTooLargeError.
+func (e FileTooLargeError) Error() string {
+	return fmt.Sprintf("file content exceeds the limit of %d bytes", e.Limit)
+}
+
+// RedirectError occurs when a redirect hop of a file download is rejected.
+type RedirectError struct {
+	// The 1-based index of the rejected redirect hop.
+	// It is zero if the HTTP client followed redirects itself so only the final URL can be checked.
+	Hop int
+	// The redirect location that was rejected.
+	Location string
+	// The reason why the redirect was rejected.
+	Err error
+}
+
+// Error implements the error interface for RedirectError.
+func (e RedirectError) Error() string {
+	if e.Hop == 0 {
+		return fmt.Sprintf("redirect to %s is rejected: %s", e.Location, e.Err)
+	}
+
+	return fmt.Sprintf("redirect hop %d to %s is rejected: %s", e.Hop, e.Location, e.Err)
+}
+
+// Unwrap returns the reason why the redirect was rejected.
+func (e RedirectError) Unwrap() error {
+	return e.Err
+}
+
 // ReadJSONOrYAMLFile reads and decodes a JSON or YAML document from the given source,
-// which may be a local file path or an HTTP/HTTPS URL.
+// which may be a local file path or an HTTP/HTTPS URL. JSONC, TOML and other formats
+// registered with [RegisterFileDecoder] are decoded too.
 func ReadJSONOrYAMLFile[T any](
 	ctx context.Context,
 	filePath string,
 	options ...DownloadFileOption,
 ) (*T, error) {
-	file, ext, err := FileReaderFromPath(ctx, filePath, options...)
+	opts := newDownloadFileOptions(options)
+
+	file, ext, err := fileReaderFromPath(ctx, filePath, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	defer CatchWarnErrorFunc(file.Close)
 
-	switch ext {
-	case ".json":
-		var result T
-
-		err = json.NewDecoder(file).Decode(&result)
-		// Ensure the reader read all data.
-		_, _ = io.Copy(io.Discard, file) //nolint:errcheck
-
-		return &result, err
-	case ".yaml", ".yml":
-		var result T
-
-		loader, err := yaml.NewLoader(file)
-		if err != nil {
-			return nil, err
-		}
-
-		err = loader.Load(&result)
-		if err != nil {
-			return nil, err
-		}
-
-		// Ensure the reader read all data.
-		_, _ = io.Copy(io.Discard, file) //nolint:errcheck
-
-		return &result, nil
-	default:
-		return nil, errUnsupportedFilePathExtension
-	}
+	return decodeJSONOrYAMLFile[T](ctx, file, filePath, ext, opts)
 }
 
 // ReadMultiFromJSONOrYAMLFile reads and decodes multiple JSON or YAML documents from the given source,
@@ -90,20 +124,45 @@ func ReadMultiFromJSONOrYAMLFile[T any](
 	filePath string,
 	options ...DownloadFileOption,
 ) ([]T, error) {
-	file, ext, err := FileReaderFromPath(ctx, filePath, options...)
+	opts := newDownloadFileOptions(options)
+
+	file, ext, err := fileReaderFromPath(ctx, filePath, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	defer CatchWarnErrorFunc(file.Close)
 
-	switch ext {
-	case ".json":
-		return LoadMultiJSONDocumentStream[T](file)
-	case ".yaml", ".yml":
-		return LoadMultiYAMLDocumentStream[T](file)
-	default:
-		return nil, errUnsupportedFilePathExtension
+	return decodeMultiJSONOrYAMLFile[T](ctx, file, filePath, ext, opts)
+}
+
+// ReadMultiFromJSONOrYAMLFileSeq returns an iterator that reads and decodes multiple JSON or YAML documents
+// from the given source one document at a time. The source is opened when the iteration starts and closed
+// when it ends. The iterator stops after the first error, when the context is canceled, or when the loop breaks.
+func ReadMultiFromJSONOrYAMLFileSeq[T any](
+	ctx context.Context,
+	filePath string,
+	options ...DownloadFileOption,
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		opts := newDownloadFileOptions(options)
+
+		file, ext, err := fileReaderFromPath(ctx, filePath, opts)
+		if err != nil {
+			var empty T
+
+			yield(empty, err)
+
+			return
+		}
+
+		defer CatchWarnErrorFunc(file.Close)
+
+		for result, err := range decodeDocumentSeq[T](ctx, file, filePath, ext, opts) {
+			if !yield(result, err) {
+				return
+			}
+		}
 	}
 }
 
@@ -111,11 +170,27 @@ func ReadMultiFromJSONOrYAMLFile[T any](
 //
 // Supported URL schemes are "http" and "https". If the provided path parses as a URL
 // with one of these schemes, an HTTP GET request is issued using http.DefaultClient
-// without an explicit timeout configured. Callers that require timeouts or custom
-// HTTP behavior should arrange this outside of this helper.
+// by default. There is no timeout, size or content type limit unless they are configured
+// with [DownloadFileWithTimeout], [DownloadFileWithMaxBodyBytes] and
+// [DownloadFileWithAllowedContentTypes]. Use [DownloadFileWithHTTPClient] for other custom behavior.
+//
+// Redirects are followed by this helper, up to 10 hops by default, and every redirect
+// location is validated against the same scheme, host and path rules as the original URL.
+// If the configured client is not an [http.Client], it may follow redirects itself,
+// in which case only the final URL of the response can be validated.
+//
+// Other schemes are opened by the [FileSource] registered for the scheme. The built-in sources are
+// "file" for local file URLs and "data" for RFC 2397 data URLs. Custom sources can be registered
+// globally with [RegisterFileSource] or per call with [DownloadFileWithSource].
+// The include and exclude path rules are applied to the path of every source. Local file URLs are checked
+// with the same rules as plain paths against the OS path that is opened, e.g. testdata/config.json for file:testdata/config.json.
 //
-// For other schemes, or if the input does not represent an http/https URL, the value
-// is treated as a filesystem path, cleaned with filepath.Clean, and opened via os.Open.
+// If the input does not have a known scheme, the value is treated as a filesystem path,
+// cleaned with filepath.Clean, and opened via os.Open.
+//
+// The returned extension is lowercase and detected from the file path. If a remote URL doesn't have
+// a supported extension, it's detected from the Content-Type header of the response. If the path has
+// no extension at all, the format is sniffed from the content. Use [DownloadFileWithFormat] to force the format.
 //
 // The caller is responsible for closing the returned io.ReadCloser when finished with it.
 func FileReaderFromPath(
@@ -123,96 +198,409 @@ func FileReaderFromPath(
 	filePath string,
 	options ...DownloadFileOption,
 ) (io.ReadCloser, string, error) {
-	defaultOptions := &downloadFileOptions{
-		HTTPClient: http.DefaultClient,
+	return fileReaderFromPath(ctx, filePath, newDownloadFileOptions(options))
+}
+
+func fileReaderFromPath(
+	ctx context.Context,
+	filePath string,
+	options *downloadFileOptions,
+) (io.ReadCloser, string, error) {
+	reader, ext, contentType, err := openFileFromPath(ctx, filePath, options)
+	if err != nil {
+		return nil, "", err
 	}
 
-	for _, opt := range options {
-		if opt == nil {
-			continue
+	// The URL path may not have an extension, e.g. https://host/api/config.
+	// Fall back to the content type of the file.
+	if !isSupportedFileExtension(ext) {
+		switch {
+		case httpheader.IsContentTypeJSON(contentType):
+			ext = ".json"
+		case httpheader.IsContentTypeYAML(contentType):
+			ext = ".yaml"
+		default:
+			if decoderExt, ok := lookupFileDecoderExtension(contentType); ok {
+				ext = decoderExt
+			}
 		}
+	}
 
-		opt(defaultOptions)
+	switch {
+	case options.Format != "":
+		ext = "." + strings.ToLower(string(options.Format))
+	case ext == "":
+		reader, ext = sniffFileFormat(reader)
+	default:
 	}
 
+	if options.MaxBodyBytes > 0 {
+		reader = &maxBytesReadCloser{
+			ReadCloser: reader,
+			limit:      options.MaxBodyBytes,
+			remaining:  options.MaxBodyBytes,
+		}
+	}
+
+	return reader, ext, nil
+}
+
+// openFileFromPath opens the file and returns the reader, the lowercase file extension
+// and the content type of the file if known.
+func openFileFromPath(
+	ctx context.Context,
+	filePath string,
+	options *downloadFileOptions,
+) (io.ReadCloser, string, string, error) {
 	filePath = strings.TrimSpace(filePath)
 	if filePath == "" {
-		return nil, "", errFilePathRequired
+		return nil, "", "", errFilePathRequired
+	}
+
+	sourceURL, source, err := lookupFileSource(filePath, options)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if source != nil {
+		return openFileSource(ctx, sourceURL, source, options)
 	}
 
 	fileURL, err := ParsePathOrURL(filePath)
 	if err != nil {
-		return nil, "", err
+		return nil, "", "", err
 	}
 
 	if slices.ContainsFunc(httpSchemes, func(scheme string) bool {
 		return strings.EqualFold(fileURL.Scheme, scheme)
 	}) {
-		return fileReaderFromURL(ctx, fileURL, filePath, defaultOptions)
+		return fileReaderFromURL(ctx, fileURL, options)
 	}
 
+	return openLocalFile(filePath, options)
+}
+
+func openLocalFile(
+	filePath string,
+	options *downloadFileOptions,
+) (io.ReadCloser, string, string, error) {
 	filePath = filepath.Clean(filePath)
 
-	err = validateFilePath(filePath, filepath.Match, defaultOptions)
+	err := validateFilePath(filePath, filepath.Match, options)
 	if err != nil {
-		return nil, "", err
+		return nil, "", "", err
 	}
 
 	ext := filepath.Ext(filePath)
+
 	reader, err := os.Open(filePath)
+	if err != nil {
+		return nil, "", "", err
+	}
 
-	return reader, strings.ToLower(ext), err
+	return reader, strings.ToLower(ext), "", nil
 }
 
 func fileReaderFromURL(
 	ctx context.Context,
 	fileURL *url.URL,
-	filePath string,
 	options *downloadFileOptions,
-) (io.ReadCloser, string, error) {
-	if len(options.AllowedHosts) > 0 || len(options.BlockedHosts) > 0 {
-		err := validateHost(fileURL.Host, fileURL.Hostname(), &ValidateHTTPURLOptions{
-			AllowedHosts: options.AllowedHosts,
-			BlockedHosts: options.BlockedHosts,
-		})
-		if err != nil {
-			return nil, "", err
-		}
+) (io.ReadCloser, string, string, error) {
+	err := validateFileURL(fileURL, options)
+	if err != nil {
+		return nil, "", "", err
 	}
 
-	if fileURL.Path != "" && fileURL.Path[0] != '/' {
-		fileURL.Path = "/" + fileURL.Path
+	cancel := context.CancelFunc(func() {})
+
+	if options.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
 	}
 
-	err := validateFilePath(fileURL.Path, path.Match, options)
+	body, contentType, err := readFileResponse(ctx, fileURL, options)
 	if err != nil {
-		return nil, "", err
+		cancel()
+
+		return nil, "", "", err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, filePath, nil)
+	ext := strings.ToLower(path.Ext(fileURL.Path))
+
+	return &cancelReadCloser{ReadCloser: body, cancel: cancel}, ext, contentType, nil
+}
+
+func readFileResponse(
+	ctx context.Context,
+	fileURL *url.URL,
+	options *downloadFileOptions,
+) (io.ReadCloser, string, error) {
+	if options.Cache != nil {
+		return readCachedFileResponse(ctx, fileURL, options)
+	}
+
+	resp, err := doFileRequest(ctx, fileURL, nil, options)
 	if err != nil {
 		return nil, "", err
 	}
 
-	resp, err := options.HTTPClient.Do(req)
+	err = validateFileResponse(resp, options)
 	if err != nil {
 		return nil, "", err
 	}
 
+	return resp.Body, resp.Header.Get(httpheader.ContentType), nil
+}
+
+// validateFileResponse validates the status, size and content type of the file response.
+// The response is closed if it's invalid.
+func validateFileResponse(resp *http.Response, options *downloadFileOptions) error {
 	if resp.StatusCode != http.StatusOK {
 		respError := httperror.NewHTTPErrorFromResponse(resp)
 		respError.Title = "Read File Failure"
 
-		return nil, "", respError
+		return respError
 	}
 
 	if resp.Body == nil || resp.Body == http.NoBody {
-		return nil, "", errFileNoContent
+		return errFileNoContent
+	}
+
+	if options.MaxBodyBytes > 0 && resp.ContentLength > options.MaxBodyBytes {
+		CloseResponse(resp)
+
+		return FileTooLargeError{Limit: options.MaxBodyBytes}
+	}
+
+	contentType := resp.Header.Get(httpheader.ContentType)
+
+	if len(options.AllowedContentTypes) > 0 &&
+		!isAllowedContentType(contentType, options.AllowedContentTypes) {
+		CloseResponse(resp)
+
+		return fmt.Errorf("%w: %q", errDisallowedContentType, contentType)
+	}
+
+	return nil
+}
+
+// sniffFileFormat detects the file extension from the first bytes of the content.
+// JSON documents start with an object or array. YAML is detected by
+// a document start marker, a sequence entry or a mapping key.
+func sniffFileFormat(reader io.ReadCloser) (io.ReadCloser, string) {
+	bufReader := bufio.NewReaderSize(reader, sniffLength)
+	result := &bufferedReadCloser{Reader: bufReader, Closer: reader}
+
+	// The error is returned again when reading the content.
+	head, _ := bufReader.Peek(sniffLength) //nolint:errcheck
+
+	head = bytes.TrimPrefix(head, []byte("\xef\xbb\xbf"))
+	head = bytes.TrimLeft(head, " \t\r\n")
+
+	switch {
+	case len(head) == 0:
+		return result, ""
+	case head[0] == '{' || head[0] == '[':
+		return result, ".json"
+	case bytes.HasPrefix(head, []byte("---")),
+		bytes.HasPrefix(head, []byte("- ")),
+		bytes.HasPrefix(head, []byte("#")),
+		bytes.Contains(head, []byte(": ")),
+		bytes.Contains(head, []byte(":\n")):
+		return result, ".yaml"
+	default:
+		return result, ""
+	}
+}
+
+func isSupportedFileExtension(ext string) bool {
+	return isNativeFileExtension(ext) || lookupFileDecoder(ext) != nil
+}
+
+// isNativeFileExtension checks if the extension is decoded natively as JSON or YAML.
+func isNativeFileExtension(ext string) bool {
+	switch ext {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// doFileRequest sends the GET request and follows redirects manually
+// so every hop can be validated before it is requested.
+func doFileRequest(
+	ctx context.Context,
+	fileURL *url.URL,
+	header http.Header,
+	options *downloadFileOptions,
+) (*http.Response, error) {
+	client := options.HTTPClient
+
+	httpClient, ok := client.(*http.Client)
+	if ok && httpClient != nil {
+		noRedirectClient := *httpClient
+		noRedirectClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+
+		client = &noRedirectClient
+	}
+
+	currentURL := fileURL
+
+	for hop := 1; ; hop++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, currentURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, values := range header {
+			req.Header[key] = values
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		location := resp.Header.Get(httpheader.Location)
+
+		if !isRedirectStatus(resp.StatusCode) || location == "" {
+			return resp, validateFinalFileURL(currentURL, resp, options)
+		}
+
+		CloseResponse(resp)
+
+		nextURL, err := currentURL.Parse(location)
+		if err != nil {
+			return nil, RedirectError{Hop: hop, Location: location, Err: err}
+		}
+
+		if hop > options.MaxRedirects {
+			return nil, RedirectError{
+				Hop:      hop,
+				Location: nextURL.String(),
+				Err:      errTooManyRedirects,
+			}
+		}
+
+		err = validateFileURL(nextURL, options)
+		if err != nil {
+			return nil, RedirectError{Hop: hop, Location: nextURL.String(), Err: err}
+		}
+
+		currentURL = nextURL
+	}
+}
+
+// validateFinalFileURL validates the URL of the final response
+// in case the HTTP client followed redirects itself.
+func validateFinalFileURL(
+	requestURL *url.URL,
+	resp *http.Response,
+	options *downloadFileOptions,
+) error {
+	if resp.Request == nil || resp.Request.URL == nil ||
+		resp.Request.URL.String() == requestURL.String() {
+		return nil
 	}
 
-	ext := strings.ToLower(filepath.Ext(filePath))
+	err := validateFileURL(resp.Request.URL, options)
+	if err != nil {
+		CloseResponse(resp)
 
-	return resp.Body, ext, nil
+		return RedirectError{Location: resp.Request.URL.String(), Err: err}
+	}
+
+	return nil
+}
+
+// validateFileURL validates the scheme, host and path of a remote file URL.
+func validateFileURL(fileURL *url.URL, options *downloadFileOptions) error {
+	allowedSchemes := options.AllowedSchemes
+	if len(allowedSchemes) == 0 {
+		allowedSchemes = httpSchemes
+	}
+
+	err := validateURLScheme(fileURL, allowedSchemes)
+	if err != nil {
+		return err
+	}
+
+	// Other schemes than http(s) are never allowed to be requested over HTTP.
+	err = validateURLScheme(fileURL, httpSchemes)
+	if err != nil {
+		return err
+	}
+
+	if fileURL.Hostname() == "" {
+		return ErrInvalidURI
+	}
+
+	if len(options.AllowedHosts) > 0 || len(options.BlockedHosts) > 0 {
+		err := validateHost(fileURL.Host, fileURL.Hostname(), &ValidateHTTPURLOptions{
+			AllowedHosts: options.AllowedHosts,
+			BlockedHosts: options.BlockedHosts,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	urlPath := fileURL.Path
+	if urlPath != "" && urlPath[0] != '/' {
+		urlPath = "/" + urlPath
+	}
+
+	return validateFilePath(urlPath, path.Match, options)
+}
+
+// isAllowedContentType checks if the content type matches one of the allowed media types.
+// JSON, YAML and XML media types also match their variants, e.g. application/vnd.api+json.
+// A wildcard subtype such as text/* matches all subtypes.
+func isAllowedContentType(contentType string, allowedContentTypes []string) bool {
+	mediaType := httpheader.ExtractBaseMediaType(contentType)
+	if mediaType == "" {
+		return false
+	}
+
+	for _, allowed := range allowedContentTypes {
+		allowed = httpheader.ExtractBaseMediaType(allowed)
+
+		var matched bool
+
+		switch {
+		case httpheader.IsContentTypeJSON(allowed):
+			matched = httpheader.IsContentTypeJSON(mediaType)
+		case httpheader.IsContentTypeYAML(allowed):
+			matched = httpheader.IsContentTypeYAML(mediaType)
+		case httpheader.IsContentTypeXML(allowed):
+			matched = httpheader.IsContentTypeXML(mediaType)
+		case strings.HasSuffix(allowed, "/*"):
+			matched = HasStringPrefixFold(mediaType, allowed[:len(allowed)-1])
+		default:
+			matched = strings.EqualFold(mediaType, allowed)
+		}
+
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isRedirectStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusMovedPermanently,
+		http.StatusFound,
+		http.StatusSeeOther,
+		http.StatusTemporaryRedirect,
+		http.StatusPermanentRedirect:
+		return true
+	default:
+		return false
+	}
 }
 
 func validateFilePath(
@@ -240,7 +628,7 @@ func validateFilePath(
 	}
 
 	for _, excludedPath := range options.ExcludePaths {
-		matched, err := filepath.Match(excludedPath, filePath)
+		matched, err := matchFunc(excludedPath, filePath)
 		if err != nil {
 			return err
 		}
@@ -253,12 +641,97 @@ func validateFilePath(
 	return nil
 }
 
+// maxBytesReadCloser returns a [FileTooLargeError] if the content exceeds the limit.
+type maxBytesReadCloser struct {
+	io.ReadCloser
+
+	limit     int64
+	remaining int64
+}
+
+func (r *maxBytesReadCloser) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, FileTooLargeError{Limit: r.limit}
+	}
+
+	// Read one more byte than remaining to detect if the content exceeds the limit.
+	// Compare without adding to remaining, which overflows if the limit is math.MaxInt64.
+	if int64(len(p))-1 > r.remaining {
+		p = p[:r.remaining+1]
+	}
+
+	n, err := r.ReadCloser.Read(p)
+	if int64(n) <= r.remaining {
+		r.remaining -= int64(n)
+
+		return n, err
+	}
+
+	n = int(r.remaining)
+	r.remaining = -1
+
+	return n, FileTooLargeError{Limit: r.limit}
+}
+
+// bufferedReadCloser reads from a buffered reader and closes the underlying reader.
+type bufferedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// cancelReadCloser cancels the request context when the body is closed.
+type cancelReadCloser struct {
+	io.ReadCloser
+
+	cancel context.CancelFunc
+}
+
+func (r *cancelReadCloser) Close() error {
+	defer r.cancel()
+
+	return r.ReadCloser.Close()
+}
+
 type downloadFileOptions struct {
-	HTTPClient   Doer
-	IncludePaths []string
-	ExcludePaths []string
-	AllowedHosts []string
-	BlockedHosts []string
+	HTTPClient     Doer
+	IncludePaths   []string
+	ExcludePaths   []string
+	AllowedHosts   []string
+	BlockedHosts   []string
+	AllowedSchemes []string
+	MaxRedirects   int
+	MaxBodyBytes   int64
+	Timeout        time.Duration
+	Format         FileFormat
+	FileSources    map[string]FileSource
+	Cache          FileCache
+	EnvLookup      EnvLookupFunc
+	Includes       bool
+	Strict         bool
+	Schema         *JSONSchema
+
+	ListMergeStrategy ListMergeStrategy
+
+	RemoteLocalIncludes bool
+
+	AllowedContentTypes []string
+}
+
+func newDownloadFileOptions(options []DownloadFileOption) *downloadFileOptions {
+	result := &downloadFileOptions{
+		HTTPClient:   http.DefaultClient,
+		MaxRedirects: defaultMaxRedirects,
+	}
+
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+
+		opt(result)
+	}
+
+	return result
 }
 
 // DownloadFileOption abstracts a function to configure options for loading files.
@@ -302,3 +775,146 @@ func DownloadFileWithBlockedHosts(hosts []string) DownloadFileOption {
 		opts.BlockedHosts = hosts
 	}
 }
+
+// DownloadFileWithAllowedSchemes creates an option to set a list of allowed schemes for URL,
+// for example, to only allow https. The list applies to the original URL and every redirect.
+// Schemes other than http and https are never allowed for remote files.
+func DownloadFileWithAllowedSchemes(schemes []string) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.AllowedSchemes = schemes
+	}
+}
+
+// DownloadFileWithMaxRedirects creates an option to set the maximum number of redirects to follow.
+// Redirects are rejected if the value is zero or negative. The default value is 10.
+func DownloadFileWithMaxRedirects(maxRedirects int) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.MaxRedirects = maxRedirects
+	}
+}
+
+// DownloadFileWithMaxBodyBytes creates an option to set the maximum number of bytes of the file content.
+// Reading more content than the limit returns a [FileTooLargeError]. Zero or negative means no limit.
+func DownloadFileWithMaxBodyBytes(limit int64) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.MaxBodyBytes = limit
+	}
+}
+
+// DownloadFileWithTimeout creates an option to set the timeout of remote file requests,
+// including redirects and reading the response body. Zero or negative means no timeout.
+func DownloadFileWithTimeout(timeout time.Duration) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.Timeout = timeout
+	}
+}
+
+// DownloadFileWithAllowedContentTypes creates an option to set a list of allowed content types
+// of remote files. JSON, YAML and XML media types also match their structured syntax suffixes,
+// e.g. application/json matches application/vnd.api+json. Responses without a content type are rejected.
+func DownloadFileWithAllowedContentTypes(contentTypes []string) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.AllowedContentTypes = contentTypes
+	}
+}
+
+// DownloadFileWithFormat creates an option to force the format of the file
+// instead of detecting it from the file extension, content type or content.
+func DownloadFileWithFormat(format FileFormat) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.Format = format
+	}
+}
+
+// DownloadFileWithSource creates an option to set a file source for a URL scheme.
+// It takes precedence over the sources registered with [RegisterFileSource].
+func DownloadFileWithSource(scheme string, source FileSource) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		if opts.FileSources == nil {
+			opts.FileSources = map[string]FileSource{}
+		}
+
+		opts.FileSources[strings.ToLower(scheme)] = source
+	}
+}
+
+// DownloadFileWithCache creates an option to cache remote files with conditional requests.
+// See [FileCache] for the caching rules.
+func DownloadFileWithCache(cache FileCache) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.Cache = cache
+	}
+}
+
+// DownloadFileWithEnvExpansion creates an option to expand environment variables
+// in scalar values of the document. See [DownloadFileWithEnvLookup] for the syntax.
+func DownloadFileWithEnvExpansion() DownloadFileOption {
+	return DownloadFileWithEnvLookup(os.LookupEnv)
+}
+
+// DownloadFileWithEnvLookup creates an option to expand variables in scalar values of the document
+// with a custom lookup function. Mapping keys and the document structure are never changed.
+// The supported expressions are:
+//
+//   - ${VAR}: the value of VAR, or an empty string if it isn't set.
+//   - ${VAR:-default}: the value of VAR, or the default if it is unset or empty.
+//   - ${VAR:?message}: the value of VAR, or a [MissingEnvError] if it is unset or empty.
+//
+// Use $${ to write a literal ${. Expressions with invalid variable names are kept as is.
+func DownloadFileWithEnvLookup(lookup EnvLookupFunc) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.EnvLookup = lookup
+	}
+}
+
+// DownloadFileWithListMergeStrategy creates an option to set the strategy to merge lists
+// of layered documents in [ReadMergedJSONOrYAMLFiles]. The default is [ListMergeReplace].
+func DownloadFileWithListMergeStrategy(strategy ListMergeStrategy) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.ListMergeStrategy = strategy
+	}
+}
+
+// DownloadFileWithIncludes creates an option to resolve includes of other files in the document.
+// YAML documents include files with the !include tag, e.g. "database: !include database.yaml".
+// JSON documents include files with objects that only have a $ref key, e.g. {"$ref": "database.json"}.
+// References starting with # are kept as is, so JSON Schema documents aren't affected.
+//
+// Relative references are resolved against the path or URL of the parent file. Included files are read
+// with the same options, including the allowed hosts and the include and exclude path rules.
+// Only the first document of an included file is used. A cycle of includes returns an [IncludeCycleError].
+//
+// Documents from http and https URLs can only include other http and https URLs, so a remote document
+// can't read local files or other file sources. Use [DownloadFileWithRemoteLocalIncludes] to allow it.
+func DownloadFileWithIncludes() DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.Includes = true
+	}
+}
+
+// DownloadFileWithRemoteLocalIncludes creates an option to allow documents from http and https URLs
+// to include local files and files of other sources, e.g. "!include file:///etc/app/secret.yaml".
+// Only use it if the remote documents are trusted as much as the local files.
+func DownloadFileWithRemoteLocalIncludes() DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.RemoteLocalIncludes = true
+	}
+}
+
+// DownloadFileWithStrict creates an option to decode documents in the strict mode.
+// The strict mode rejects fields that don't exist in the target struct and duplicate keys of
+// JSON objects and YAML mappings. Every duplicate key is reported with its location in a [ConfigDecodeErrors].
+func DownloadFileWithStrict() DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.Strict = true
+	}
+}
+
+// DownloadFileWithSchema creates an option to validate every document against the JSON Schema
+// after env expansion and includes, before it's decoded into the target type.
+// Merged documents are validated once after merging. Violations are returned as a [SchemaValidationError].
+func DownloadFileWithSchema(schema *JSONSchema) DownloadFileOption {
+	return func(opts *downloadFileOptions) {
+		opts.Schema = schema
+	}
+}
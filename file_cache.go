@@ -0,0 +1,372 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/relychan/goutils/httpheader"
+)
+
+// FileCacheEntry represents a cached remote file.
+type FileCacheEntry struct {
+	// The ETag header of the response.
+	ETag string `json:"etag,omitempty"`
+	// The Last-Modified header of the response.
+	LastModified string `json:"lastModified,omitempty"`
+	// The Content-Type header of the response.
+	ContentType string `json:"contentType,omitempty"`
+	// The time until the entry is fresh and can be served without a request.
+	// The zero value means the entry must be revalidated on every read.
+	ExpiresAt time.Time `json:"expiresAt,omitzero"`
+	// The body of the response.
+	Content []byte `json:"content"`
+}
+
+// IsFresh checks if the entry can be served without revalidation at the given time.
+func (fce FileCacheEntry) IsFresh(now time.Time) bool {
+	return !fce.ExpiresAt.IsZero() && now.Before(fce.ExpiresAt)
+}
+
+// FileCache abstracts a storage of remote files for conditional requests.
+//
+// Remote files are stored if the response has an ETag or Last-Modified header, or a positive
+// Cache-Control max-age, and the response doesn't have the Cache-Control no-store directive.
+// Fresh entries are served without a request. Stale entries are revalidated with
+// the If-None-Match and If-Modified-Since headers, and served from the cache on 304 Not Modified.
+type FileCache interface {
+	// Get returns the cache entry of the key. It returns nil if the entry doesn't exist.
+	Get(ctx context.Context, key string) (*FileCacheEntry, error)
+	// Set stores the cache entry of the key.
+	Set(ctx context.Context, key string, entry *FileCacheEntry) error
+}
+
+// MemoryFileCache is a [FileCache] that stores entries in memory.
+type MemoryFileCache struct {
+	lock    sync.RWMutex
+	entries map[string]*FileCacheEntry
+}
+
+var _ FileCache = (*MemoryFileCache)(nil)
+
+// NewMemoryFileCache creates a file cache that stores entries in memory.
+func NewMemoryFileCache() *MemoryFileCache {
+	return &MemoryFileCache{
+		entries: map[string]*FileCacheEntry{},
+	}
+}
+
+// Get returns the cache entry of the key. It returns nil if the entry doesn't exist.
+func (mfc *MemoryFileCache) Get(_ context.Context, key string) (*FileCacheEntry, error) {
+	mfc.lock.RLock()
+	defer mfc.lock.RUnlock()
+
+	entry, ok := mfc.entries[key]
+	if !ok {
+		return nil, nil
+	}
+
+	result := *entry
+
+	return &result, nil
+}
+
+// Set stores the cache entry of the key.
+func (mfc *MemoryFileCache) Set(_ context.Context, key string, entry *FileCacheEntry) error {
+	if entry == nil {
+		return nil
+	}
+
+	value := *entry
+
+	mfc.lock.Lock()
+	defer mfc.lock.Unlock()
+
+	mfc.entries[key] = &value
+
+	return nil
+}
+
+// DiskFileCache is a [FileCache] that stores entries as JSON files in a directory.
+type DiskFileCache struct {
+	dir string
+}
+
+var _ FileCache = (*DiskFileCache)(nil)
+
+// NewDiskFileCache creates a file cache that stores entries in the directory.
+// The directory is created on the first write if it doesn't exist.
+func NewDiskFileCache(dir string) *DiskFileCache {
+	return &DiskFileCache{dir: dir}
+}
+
+// Get returns the cache entry of the key. It returns nil if the entry doesn't exist.
+func (dfc *DiskFileCache) Get(_ context.Context, key string) (*FileCacheEntry, error) {
+	rawEntry, err := os.ReadFile(dfc.entryPath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var entry FileCacheEntry
+
+	err = json.Unmarshal(rawEntry, &entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file cache entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// Set stores the cache entry of the key.
+// The entry is written to a temporary file and renamed so readers never see partial content.
+func (dfc *DiskFileCache) Set(_ context.Context, key string, entry *FileCacheEntry) error {
+	if entry == nil {
+		return nil
+	}
+
+	rawEntry, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dfc.dir, 0o750)
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(dfc.dir, ".file-cache-*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tempFile.Write(rawEntry)
+
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tempFile.Name(), dfc.entryPath(key))
+	}
+
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+
+		return err
+	}
+
+	return nil
+}
+
+func (dfc *DiskFileCache) entryPath(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return filepath.Join(dfc.dir, hex.EncodeToString(hash[:])+".json")
+}
+
+// readCachedFileResponse reads the remote file through the file cache.
+func readCachedFileResponse(
+	ctx context.Context,
+	fileURL *url.URL,
+	options *downloadFileOptions,
+) (io.ReadCloser, string, error) {
+	key := fileURL.String()
+
+	entry, err := options.Cache.Get(ctx, key)
+	if err != nil {
+		slog.Warn("failed to get file cache entry: "+err.Error(), slog.String("url", key))
+
+		entry = nil
+	}
+
+	if entry != nil && entry.IsFresh(time.Now()) {
+		return readFileCacheEntry(entry, options)
+	}
+
+	header := http.Header{}
+
+	if entry != nil {
+		if entry.ETag != "" {
+			header.Set(httpheader.IfNoneMatch, entry.ETag)
+		}
+
+		if entry.LastModified != "" {
+			header.Set(httpheader.IfModifiedSince, entry.LastModified)
+		}
+	}
+
+	resp, err := doFileRequest(ctx, fileURL, header, options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		CloseResponse(resp)
+
+		entry.ExpiresAt = fileCacheExpiry(resp.Header)
+
+		if etag := resp.Header.Get(httpheader.ETag); etag != "" {
+			entry.ETag = etag
+		}
+
+		setFileCacheEntry(ctx, options.Cache, key, entry)
+
+		return readFileCacheEntry(entry, options)
+	}
+
+	err = validateFileResponse(resp, options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := readFileResponseBody(resp, options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	contentType := resp.Header.Get(httpheader.ContentType)
+
+	if isFileResponseCacheable(resp.Header) {
+		setFileCacheEntry(ctx, options.Cache, key, &FileCacheEntry{
+			ETag:         resp.Header.Get(httpheader.ETag),
+			LastModified: resp.Header.Get(httpheader.LastModified),
+			ContentType:  contentType,
+			ExpiresAt:    fileCacheExpiry(resp.Header),
+			Content:      content,
+		})
+	}
+
+	return io.NopCloser(bytes.NewReader(content)), contentType, nil
+}
+
+// readFileResponseBody reads the whole response body within the size limit and closes it.
+func readFileResponseBody(resp *http.Response, options *downloadFileOptions) ([]byte, error) {
+	defer CatchWarnErrorFunc(resp.Body.Close)
+
+	if options.MaxBodyBytes <= 0 {
+		return io.ReadAll(resp.Body)
+	}
+
+	// Read one more byte than the limit to detect if the content exceeds it, unless the limit can't be exceeded.
+	content, err := io.ReadAll(io.LimitReader(resp.Body, min(options.MaxBodyBytes, math.MaxInt64-1)+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(content)) > options.MaxBodyBytes {
+		return nil, FileTooLargeError{Limit: options.MaxBodyBytes}
+	}
+
+	return content, nil
+}
+
+func readFileCacheEntry(
+	entry *FileCacheEntry,
+	options *downloadFileOptions,
+) (io.ReadCloser, string, error) {
+	if options.MaxBodyBytes > 0 && int64(len(entry.Content)) > options.MaxBodyBytes {
+		return nil, "", FileTooLargeError{Limit: options.MaxBodyBytes}
+	}
+
+	if len(options.AllowedContentTypes) > 0 &&
+		!isAllowedContentType(entry.ContentType, options.AllowedContentTypes) {
+		return nil, "", fmt.Errorf("%w: %q", errDisallowedContentType, entry.ContentType)
+	}
+
+	return io.NopCloser(bytes.NewReader(entry.Content)), entry.ContentType, nil
+}
+
+func setFileCacheEntry(ctx context.Context, cache FileCache, key string, entry *FileCacheEntry) {
+	err := cache.Set(ctx, key, entry)
+	if err != nil {
+		slog.Warn("failed to set file cache entry: "+err.Error(), slog.String("url", key))
+	}
+}
+
+// isFileResponseCacheable checks if the response can be stored or revalidated later.
+func isFileResponseCacheable(header http.Header) bool {
+	maxAge, noStore := parseFileCacheControl(header.Get(httpheader.CacheControl))
+	if noStore {
+		return false
+	}
+
+	return maxAge > 0 ||
+		header.Get(httpheader.ETag) != "" ||
+		header.Get(httpheader.LastModified) != ""
+}
+
+// fileCacheExpiry returns the expiry time from the Cache-Control max-age directive.
+func fileCacheExpiry(header http.Header) time.Time {
+	maxAge, _ := parseFileCacheControl(header.Get(httpheader.CacheControl))
+	if maxAge <= 0 {
+		return time.Time{}
+	}
+
+	return time.Now().Add(maxAge)
+}
+
+// parseFileCacheControl parses the max-age and no-store directives of the Cache-Control header.
+// The no-cache directive requires revalidation, so it's treated as a zero max-age.
+func parseFileCacheControl(value string) (time.Duration, bool) {
+	var maxAge time.Duration
+
+	var noCache, noStore bool
+
+	for directive := range strings.SplitSeq(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "no-store":
+			noStore = true
+		case "no-cache":
+			noCache = true
+		case "max-age":
+			seconds, err := strconv.ParseInt(strings.Trim(strings.TrimSpace(arg), `"`), 10, 64)
+			if err == nil && seconds > 0 {
+				maxAge = time.Duration(min(seconds, math.MaxInt64/int64(time.Second))) * time.Second
+			}
+		default:
+		}
+	}
+
+	if noCache {
+		maxAge = 0
+	}
+
+	return maxAge, noStore
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadJSONOrYAMLFile_Cache(t *testing.T) {
+	var requests, notModified atomic.Int32
+
+	const lastModified = "Wed, 21 Oct 2026 07:28:00 GMT"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		switch r.URL.Path {
+		case "/etag.json":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+
+				return
+			}
+
+			w.Header().Set("ETag", `"v1"`)
+		case "/last-modified.json":
+			if r.Header.Get("If-Modified-Since") == lastModified {
+				notModified.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+
+				return
+			}
+
+			w.Header().Set("Last-Modified", lastModified)
+		case "/max-age.json":
+			w.Header().Set("Cache-Control", "public, max-age=60")
+		case "/no-store.json":
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Cache-Control", "no-store")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"foo": "bar"}`))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		Name                string
+		Path                string
+		ExpectedRequests    int32
+		ExpectedNotModified int32
+	}{
+		{
+			Name:                "etag",
+			Path:                "/etag.json",
+			ExpectedRequests:    3,
+			ExpectedNotModified: 2,
+		},
+		{
+			Name:                "last_modified",
+			Path:                "/last-modified.json",
+			ExpectedRequests:    3,
+			ExpectedNotModified: 2,
+		},
+		{
+			Name:             "max_age",
+			Path:             "/max-age.json",
+			ExpectedRequests: 1,
+		},
+		{
+			Name:             "no_store",
+			Path:             "/no-store.json",
+			ExpectedRequests: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			requests.Store(0)
+			notModified.Store(0)
+
+			cache := NewMemoryFileCache()
+
+			for range 3 {
+				result, err := ReadJSONOrYAMLFile[map[string]string](
+					context.Background(),
+					server.URL+tc.Path,
+					DownloadFileWithCache(cache),
+				)
+				if err != nil {
+					t.Fatalf("expected nil error, got: %s", err)
+				}
+
+				if (*result)["foo"] != "bar" {
+					t.Fatalf("expected bar, got: %v", *result)
+				}
+			}
+
+			if requests.Load() != tc.ExpectedRequests {
+				t.Errorf("expected %d requests, got: %d", tc.ExpectedRequests, requests.Load())
+			}
+
+			if notModified.Load() != tc.ExpectedNotModified {
+				t.Errorf("expected %d not modified responses, got: %d", tc.ExpectedNotModified, notModified.Load())
+			}
+		})
+	}
+
+	t.Run("disk_cache", func(t *testing.T) {
+		requests.Store(0)
+		notModified.Store(0)
+
+		dir := t.TempDir()
+
+		for range 2 {
+			// A new cache instance must load the entries persisted by the previous one.
+			result, err := ReadJSONOrYAMLFile[map[string]string](
+				context.Background(),
+				server.URL+"/etag.json",
+				DownloadFileWithCache(NewDiskFileCache(dir)),
+			)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			if (*result)["foo"] != "bar" {
+				t.Fatalf("expected bar, got: %v", *result)
+			}
+		}
+
+		if notModified.Load() != 1 {
+			t.Errorf("expected 1 not modified response, got: %d", notModified.Load())
+		}
+	})
+
+	t.Run("disallowed_cached_content_type", func(t *testing.T) {
+		cache := NewMemoryFileCache()
+
+		_, err := ReadJSONOrYAMLFile[map[string]string](
+			context.Background(),
+			server.URL+"/max-age.json",
+			DownloadFileWithCache(cache),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		_, err = ReadJSONOrYAMLFile[map[string]string](
+			context.Background(),
+			server.URL+"/max-age.json",
+			DownloadFileWithCache(cache),
+			DownloadFileWithAllowedContentTypes([]string{"application/yaml"}),
+		)
+		if err == nil {
+			t.Fatal("expected disallowed content type error, got nil")
+		}
+	})
+}
+
+func TestParseFileCacheControl(t *testing.T) {
+	testCases := []struct {
+		Value   string
+		MaxAge  time.Duration
+		NoStore bool
+	}{
+		{Value: "", MaxAge: 0},
+		{Value: "max-age=60", MaxAge: time.Minute},
+		{Value: `public, MAX-AGE="120"`, MaxAge: 2 * time.Minute},
+		{Value: "max-age=60, no-cache", MaxAge: 0},
+		{Value: "no-store, max-age=60", MaxAge: time.Minute, NoStore: true},
+		{Value: "max-age=abc", MaxAge: 0},
+		{Value: "max-age=99999999999", MaxAge: math.MaxInt64 / time.Second * time.Second},
+	}
+
+	for _, tc := range testCases {
+		maxAge, noStore := parseFileCacheControl(tc.Value)
+		if maxAge != tc.MaxAge || noStore != tc.NoStore {
+			t.Errorf("%q: expected (%s, %t), got: (%s, %t)", tc.Value, tc.MaxAge, tc.NoStore, maxAge, noStore)
+		}
+	}
+}
@@ -0,0 +1,893 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"strconv"
+	"strings"
+
+	"github.com/relychan/goutils/httperror"
+	"go.yaml.in/yaml/v4"
+)
+
+// ConfigDecodeError occurs when a document of a config file can't be decoded.
+type ConfigDecodeError struct {
+	// The path of the document file.
+	FilePath string
+	// The index of the document in a multi-document file.
+	DocumentIndex int
+	// The line of the error in the file, starting from 1. It's 0 if unknown.
+	Line int
+	// The column of the error in the line, starting from 1. It's 0 if unknown.
+	Column int
+	// The JSON Pointer of the failing field in the document. It's empty if unknown.
+	Pointer string
+	// The underlying error of the decoder.
+	Err error
+}
+
+// Error implements the error interface for ConfigDecodeError.
+func (e ConfigDecodeError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString("failed to decode")
+
+	if e.FilePath != "" {
+		sb.WriteByte(' ')
+		sb.WriteString(e.FilePath)
+	}
+
+	if e.DocumentIndex > 0 {
+		sb.WriteString(" document ")
+		sb.WriteString(strconv.Itoa(e.DocumentIndex))
+	}
+
+	e.writeLocation(&sb)
+	sb.WriteString(": ")
+	sb.WriteString(e.Err.Error())
+
+	return sb.String()
+}
+
+// Unwrap returns the underlying error of the decoder.
+func (e ConfigDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError converts the error to a validation error, so API handlers can return it directly.
+func (e ConfigDecodeError) ValidationError() httperror.ValidationError {
+	var sb strings.Builder
+
+	sb.WriteString(e.Err.Error())
+	e.writeLocation(&sb)
+
+	return httperror.ValidationError{
+		Detail:  sb.String(),
+		Pointer: e.Pointer,
+	}
+}
+
+func (e ConfigDecodeError) writeLocation(sb *strings.Builder) {
+	if e.Line > 0 {
+		sb.WriteString(" at line ")
+		sb.WriteString(strconv.Itoa(e.Line))
+
+		if e.Column > 0 {
+			sb.WriteString(", column ")
+			sb.WriteString(strconv.Itoa(e.Column))
+		}
+	}
+
+	if e.Pointer != "" {
+		sb.WriteString(" (")
+		sb.WriteString(e.Pointer)
+		sb.WriteByte(')')
+	}
+}
+
+// ConfigDecodeErrors is a list of errors found in the documents of a config file.
+type ConfigDecodeErrors []ConfigDecodeError
+
+// Error implements the error interface for ConfigDecodeErrors.
+func (e ConfigDecodeErrors) Error() string {
+	messages := make([]string, len(e))
+
+	for i, item := range e {
+		messages[i] = item.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the list of errors.
+func (e ConfigDecodeErrors) Unwrap() []error {
+	result := make([]error, len(e))
+
+	for i, item := range e {
+		result[i] = item
+	}
+
+	return result
+}
+
+// ValidationErrors converts the errors to validation errors, so API handlers can return them directly.
+func (e ConfigDecodeErrors) ValidationErrors() []httperror.ValidationError {
+	result := make([]httperror.ValidationError, len(e))
+
+	for i, item := range e {
+		result[i] = item.ValidationError()
+	}
+
+	return result
+}
+
+// SchemaValidationError occurs when a document of a config file doesn't match the JSON Schema.
+type SchemaValidationError struct {
+	// The path or URL of the file.
+	FilePath string
+	// The zero-based index of the document in a multi-document file.
+	DocumentIndex int
+	// Every violation of the schema with the JSON Pointer of the value.
+	Errors []httperror.ValidationError
+}
+
+// Error implements the error interface for SchemaValidationError.
+func (e SchemaValidationError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString("document")
+
+	if e.FilePath != "" {
+		sb.WriteString(" of ")
+		sb.WriteString(e.FilePath)
+	}
+
+	if e.DocumentIndex > 0 {
+		sb.WriteString(" at ")
+		sb.WriteString(strconv.Itoa(e.DocumentIndex))
+	}
+
+	sb.WriteString(" doesn't match the schema")
+
+	for i, item := range e.Errors {
+		if i == 0 {
+			sb.WriteString(": ")
+		} else {
+			sb.WriteString("; ")
+		}
+
+		sb.WriteString(item.Detail)
+
+		if item.Pointer != "" {
+			sb.WriteString(" (")
+			sb.WriteString(item.Pointer)
+			sb.WriteByte(')')
+		}
+	}
+
+	return sb.String()
+}
+
+// ValidationErrors returns the violations of the schema, so API handlers can return them directly.
+func (e SchemaValidationError) ValidationErrors() []httperror.ValidationError {
+	return e.Errors
+}
+
+// decodeJSONOrYAMLFile decodes a JSON or YAML document from the reader by the file extension.
+func decodeJSONOrYAMLFile[T any](
+	ctx context.Context,
+	reader io.Reader,
+	filePath string,
+	ext string,
+	options *downloadFileOptions,
+) (*T, error) {
+	results, err := decodeDocuments[T](ctx, reader, filePath, ext, options, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, io.EOF
+	}
+
+	return &results[0], nil
+}
+
+// decodeMultiJSONOrYAMLFile decodes multiple JSON or YAML documents from the reader by the file extension.
+func decodeMultiJSONOrYAMLFile[T any](
+	ctx context.Context,
+	reader io.Reader,
+	filePath string,
+	ext string,
+	options *downloadFileOptions,
+) ([]T, error) {
+	return decodeDocuments[T](ctx, reader, filePath, ext, options, -1)
+}
+
+// hasDocumentTransforms checks if documents must be transformed or validated as generic values before decoding.
+func (opts *downloadFileOptions) hasDocumentTransforms() bool {
+	return opts.EnvLookup != nil || opts.Includes || opts.Schema != nil
+}
+
+// validateDocument validates the generic value of the document against the schema of the options.
+func (opts *downloadFileOptions) validateDocument(doc document, value any) error {
+	if opts.Schema == nil {
+		return nil
+	}
+
+	errs := opts.Schema.Validate(value)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return SchemaValidationError{
+		FilePath:      doc.FilePath,
+		DocumentIndex: doc.Index,
+		Errors:        errs,
+	}
+}
+
+// decodeYAMLNode decodes the node into the target. The strict mode rejects unknown fields.
+func decodeYAMLNode(node *yaml.Node, target any, strict bool) error {
+	if strict {
+		return node.Load(target, yaml.WithKnownFields())
+	}
+
+	return node.Decode(target)
+}
+
+// errStopDocuments stops walking documents when the consumer doesn't want more.
+var errStopDocuments = errors.New("stop documents")
+
+// decodeDocuments decodes up to limit documents of the file. A negative limit reads all documents.
+// Errors of the decoders are returned as [ConfigDecodeError].
+func decodeDocuments[T any](
+	ctx context.Context,
+	reader io.Reader,
+	filePath string,
+	ext string,
+	options *downloadFileOptions,
+	limit int,
+) ([]T, error) {
+	var results []T
+
+	err := walkDecodedDocuments(ctx, reader, filePath, ext, options, limit, func(result T) bool {
+		results = append(results, result)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// decodeDocumentSeq returns an iterator that decodes the documents of the file one at a time.
+// It stops after the first error.
+func decodeDocumentSeq[T any](
+	ctx context.Context,
+	reader io.Reader,
+	filePath string,
+	ext string,
+	options *downloadFileOptions,
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := walkDecodedDocuments(ctx, reader, filePath, ext, options, -1, func(result T) bool {
+			return yield(result, nil)
+		})
+		if err != nil {
+			var empty T
+
+			yield(empty, err)
+		}
+	}
+}
+
+// walkDecodedDocuments decodes up to limit documents of the file and calls yield for each document
+// until yield returns false. A negative limit reads all documents.
+func walkDecodedDocuments[T any](
+	ctx context.Context,
+	reader io.Reader,
+	filePath string,
+	ext string,
+	options *downloadFileOptions,
+	limit int,
+	yield func(T) bool,
+) error {
+	parent := document{FilePath: filePath}
+
+	var err error
+
+	switch ext {
+	case ".json":
+		if options.hasDocumentTransforms() {
+			err = decodeTransformedJSONDocuments(ctx, reader, parent, options, limit, yield)
+		} else {
+			err = decodeJSONDocuments(ctx, reader, parent, options, limit, yield)
+		}
+	case ".yaml", ".yml":
+		err = decodeYAMLDocuments(ctx, reader, parent, options, limit, yield)
+	default:
+		err = decodeRegisteredDocuments(ctx, reader, parent, ext, options, limit, yield)
+	}
+
+	if errors.Is(err, errStopDocuments) {
+		return nil
+	}
+
+	return err
+}
+
+// decodeRegisteredDocuments decodes documents of a registered format through its JSON stream.
+func decodeRegisteredDocuments[T any](
+	ctx context.Context,
+	reader io.Reader,
+	parent document,
+	ext string,
+	options *downloadFileOptions,
+	limit int,
+	yield func(T) bool,
+) error {
+	decoder := lookupFileDecoder(ext)
+	if decoder == nil {
+		return errUnsupportedFilePathExtension
+	}
+
+	jsonReader, err := decoder.convertToJSON(reader, parent)
+	if err != nil {
+		return err
+	}
+
+	if decoder.PreservesOffsets && !options.hasDocumentTransforms() {
+		return decodeJSONDocuments(ctx, jsonReader, parent, options, limit, yield)
+	}
+
+	return decodeTransformedJSONDocuments(ctx, jsonReader, parent, options, limit, yield)
+}
+
+// decodeJSONDocuments decodes JSON documents from the stream directly.
+// The read content is recorded to locate the line and column of errors.
+func decodeJSONDocuments[T any](
+	ctx context.Context,
+	reader io.Reader,
+	parent document,
+	options *downloadFileOptions,
+	limit int,
+	yield func(T) bool,
+) error {
+	content := &jsonStreamContent{}
+	decoder := json.NewDecoder(io.TeeReader(reader, content))
+
+	if options.Strict {
+		decoder.DisallowUnknownFields()
+	}
+
+	for index := 0; limit < 0 || index < limit; index++ {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		var result T
+
+		offset := decoder.InputOffset()
+
+		err = decoder.Decode(&result)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		doc := parent
+		doc.Index = index
+
+		if err != nil {
+			return newJSONConfigDecodeError(doc, content, offset, err)
+		}
+
+		if options.Strict {
+			err = checkJSONDuplicateKeys(doc, content, offset, decoder.InputOffset())
+			if err != nil {
+				return err
+			}
+		}
+
+		content.discard(decoder.InputOffset())
+
+		if !yield(result) {
+			return errStopDocuments
+		}
+	}
+
+	// Ensure the reader read all data.
+	_, _ = io.Copy(io.Discard, reader) //nolint:errcheck
+
+	return nil
+}
+
+func decodeTransformedJSONDocuments[T any](
+	ctx context.Context,
+	reader io.Reader,
+	parent document,
+	options *downloadFileOptions,
+	limit int,
+	yield func(T) bool,
+) error {
+	return walkJSONDocuments(ctx, reader, parent, options, limit, func(doc document, value any) error {
+		err := options.validateDocument(doc, value)
+		if err != nil {
+			return err
+		}
+
+		rawBytes, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		var result T
+
+		decoder := json.NewDecoder(bytes.NewReader(rawBytes))
+
+		if options.Strict {
+			decoder.DisallowUnknownFields()
+		}
+
+		err = decoder.Decode(&result)
+		if err != nil {
+			// The offset of the re-encoded document doesn't match the file.
+			return newJSONConfigDecodeError(doc, nil, 0, err)
+		}
+
+		if !yield(result) {
+			return errStopDocuments
+		}
+
+		return nil
+	})
+}
+
+func decodeYAMLDocuments[T any](
+	ctx context.Context,
+	reader io.Reader,
+	parent document,
+	options *downloadFileOptions,
+	limit int,
+	yield func(T) bool,
+) error {
+	return walkYAMLDocuments(ctx, reader, parent, options, limit, func(doc document, node *yaml.Node) error {
+		if options.Schema != nil {
+			var value any
+
+			err := node.Decode(&value)
+			if err != nil {
+				return newYAMLConfigDecodeError(doc, node, err)
+			}
+
+			err = options.validateDocument(doc, value)
+			if err != nil {
+				return err
+			}
+		}
+
+		var result T
+
+		err := decodeYAMLNode(node, &result, options.Strict)
+		if err != nil {
+			return newYAMLConfigDecodeError(doc, node, err)
+		}
+
+		if !yield(result) {
+			return errStopDocuments
+		}
+
+		return nil
+	})
+}
+
+// walkJSONDocuments loads up to limit JSON documents of the parent file as generic values with json.Number numbers,
+// applies the transforms of the options and calls the callback for each document.
+func walkJSONDocuments(
+	ctx context.Context,
+	reader io.Reader,
+	parent document,
+	options *downloadFileOptions,
+	limit int,
+	callback func(doc document, value any) error,
+) error {
+	content := &jsonStreamContent{}
+	decoder := json.NewDecoder(io.TeeReader(reader, content))
+	decoder.UseNumber()
+
+	for index := 0; limit < 0 || index < limit; index++ {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		var value any
+
+		offset := decoder.InputOffset()
+
+		err = decoder.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		doc := parent
+		doc.Index = index
+
+		if err != nil {
+			return newJSONConfigDecodeError(doc, content, 0, err)
+		}
+
+		if options.Strict {
+			err = checkJSONDuplicateKeys(doc, content, offset, decoder.InputOffset())
+			if err != nil {
+				return err
+			}
+		}
+
+		content.discard(decoder.InputOffset())
+
+		value, err = options.transformJSONValue(ctx, doc, value)
+		if err != nil {
+			return err
+		}
+
+		err = callback(doc, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Ensure the reader read all data.
+	_, _ = io.Copy(io.Discard, reader) //nolint:errcheck
+
+	return nil
+}
+
+// walkYAMLDocuments loads up to limit YAML documents of the parent file as nodes,
+// applies the transforms of the options and calls the callback for each document.
+func walkYAMLDocuments(
+	ctx context.Context,
+	reader io.Reader,
+	parent document,
+	options *downloadFileOptions,
+	limit int,
+	callback func(doc document, node *yaml.Node) error,
+) error {
+	loader, err := yaml.NewLoader(reader)
+	if err != nil {
+		return err
+	}
+
+	for index := 0; limit < 0 || index < limit; index++ {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+
+		var node yaml.Node
+
+		err = loader.Load(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		doc := parent
+		doc.Index = index
+
+		if err != nil {
+			return newYAMLConfigDecodeError(doc, nil, err)
+		}
+
+		if options.Strict {
+			err = checkYAMLDuplicateKeys(doc, &node)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = options.transformYAMLNode(ctx, doc, &node)
+		if err != nil {
+			return err
+		}
+
+		err = callback(doc, &node)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Ensure the reader read all data.
+	_, _ = io.Copy(io.Discard, reader) //nolint:errcheck
+
+	return nil
+}
+
+// jsonStreamContent records the content read by a JSON decoder to locate the line and column of errors.
+// The content of decoded documents is discarded up to the input offset of the decoder,
+// so the memory doesn't grow with the stream, even if all documents are on one line.
+type jsonStreamContent struct {
+	buffer bytes.Buffer
+	// The stream offset of the first recorded byte.
+	start int64
+	// The number of discarded lines.
+	lines int
+	// The number of discarded bytes of the first recorded line.
+	column int
+}
+
+// Write implements the io.Writer interface.
+func (c *jsonStreamContent) Write(p []byte) (int, error) {
+	return c.buffer.Write(p)
+}
+
+// discard drops the recorded content before the stream offset.
+func (c *jsonStreamContent) discard(offset int64) {
+	size := min(offset-c.start, int64(c.buffer.Len()))
+	if size <= 0 {
+		return
+	}
+
+	data := c.buffer.Next(int(size))
+
+	if index := bytes.LastIndexByte(data, '\n'); index >= 0 {
+		c.lines += bytes.Count(data, []byte{'\n'})
+		c.column = len(data) - index - 1
+	} else {
+		c.column += len(data)
+	}
+
+	c.start += size
+}
+
+// bytes returns the recorded content between the start and end stream offsets.
+func (c *jsonStreamContent) bytes(start int64, end int64) []byte {
+	data := c.buffer.Bytes()
+
+	return data[max(start-c.start, 0):min(end-c.start, int64(len(data)))]
+}
+
+// skip returns the stream offset of the first byte from the offset that isn't in the cutset.
+func (c *jsonStreamContent) skip(offset int64, cutset string) int64 {
+	data := c.buffer.Bytes()
+
+	for offset >= c.start && offset-c.start < int64(len(data)) &&
+		strings.IndexByte(cutset, data[offset-c.start]) >= 0 {
+		offset++
+	}
+
+	return offset
+}
+
+// location returns the 1-based line and column of the last byte read
+// when the decoder failed after reading offset bytes of the stream.
+func (c *jsonStreamContent) location(offset int64) (int, int) {
+	if c == nil {
+		return 0, 0
+	}
+
+	line, column := findLineColumn(c.buffer.Bytes(), offset-c.start)
+	if line == 1 {
+		column += c.column
+	}
+
+	if line > 0 {
+		line += c.lines
+	}
+
+	return line, column
+}
+
+// document holds the location of a document being decoded.
+type document struct {
+	FilePath string
+	Index    int
+	// The paths of the files that include the document, from the root.
+	Parents []string
+}
+
+func (opts *downloadFileOptions) transformJSONValue(
+	ctx context.Context,
+	doc document,
+	value any,
+) (any, error) {
+	var err error
+
+	if opts.EnvLookup != nil {
+		expander := envExpander{lookup: opts.EnvLookup, document: doc}
+
+		value, err = expander.expandJSONValue(value, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Includes {
+		resolver := includeResolver{options: opts, document: doc}
+
+		value, err = resolver.resolveJSONValue(ctx, value, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
+
+func (opts *downloadFileOptions) transformYAMLNode(
+	ctx context.Context,
+	doc document,
+	node *yaml.Node,
+) error {
+	if opts.EnvLookup != nil {
+		expander := envExpander{lookup: opts.EnvLookup, document: doc}
+
+		err := expander.expandYAMLNode(node, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Includes {
+		resolver := includeResolver{options: opts, document: doc}
+
+		return resolver.resolveYAMLNode(ctx, node, "")
+	}
+
+	return nil
+}
+
+func newJSONConfigDecodeError(doc document, content *jsonStreamContent, offset int64, err error) error {
+	result := ConfigDecodeError{
+		FilePath:      doc.FilePath,
+		DocumentIndex: doc.Index,
+		Err:           err,
+	}
+
+	var syntaxErr *json.SyntaxError
+
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		// The offset of syntax errors is relative to the stream.
+		result.Line, result.Column = content.location(syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		// The offset of type errors is relative to the document, excluding leading whitespace.
+		if content != nil {
+			result.Line, result.Column = content.location(content.skip(offset, " \t\r\n") + typeErr.Offset)
+		}
+
+		if typeErr.Field != "" {
+			for field := range strings.SplitSeq(typeErr.Field, ".") {
+				result.Pointer = appendJSONPointer(result.Pointer, field)
+			}
+		}
+	default:
+	}
+
+	return result
+}
+
+func newYAMLConfigDecodeError(doc document, node *yaml.Node, err error) error {
+	result := ConfigDecodeError{
+		FilePath:      doc.FilePath,
+		DocumentIndex: doc.Index,
+		Err:           err,
+	}
+
+	var loadErrs *yaml.LoadErrors
+
+	// Report every error of the constructor, e.g. all unknown fields in strict mode.
+	if errors.As(err, &loadErrs) && len(loadErrs.Errors) > 1 {
+		results := make(ConfigDecodeErrors, len(loadErrs.Errors))
+
+		for i, loadErr := range loadErrs.Errors {
+			results[i] = newYAMLConfigDecodeErrorFromMark(doc, node, loadErr.Mark, loadErr)
+		}
+
+		return results
+	}
+
+	var loadErr *yaml.LoadError
+
+	if errors.As(err, &loadErr) {
+		return newYAMLConfigDecodeErrorFromMark(doc, node, loadErr.Mark, err)
+	}
+
+	return result
+}
+
+func newYAMLConfigDecodeErrorFromMark(
+	doc document,
+	node *yaml.Node,
+	mark yaml.Mark,
+	err error,
+) ConfigDecodeError {
+	result := ConfigDecodeError{
+		FilePath:      doc.FilePath,
+		DocumentIndex: doc.Index,
+		Line:          mark.Line,
+		Column:        mark.Column,
+		Err:           err,
+	}
+
+	if node != nil && result.Line > 0 {
+		result.Pointer, _ = findYAMLNodePointer(node, result.Line, result.Column, "")
+	}
+
+	return result
+}
+
+// findLineColumn returns the 1-based line and column of the last byte read
+// when the decoder failed after reading offset bytes of the content.
+// It returns zeros if the offset is out of the content.
+func findLineColumn(content []byte, offset int64) (int, int) {
+	if offset <= 0 || offset > int64(len(content)) {
+		return 0, 0
+	}
+
+	head := content[:offset-1]
+	line := bytes.Count(head, []byte{'\n'}) + 1
+	column := len(head) - bytes.LastIndexByte(head, '\n')
+
+	return line, column
+}
+
+// findYAMLNodePointer returns the JSON Pointer of the deepest node at the line and column.
+func findYAMLNodePointer(node *yaml.Node, line int, column int, pointer string) (string, bool) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, item := range node.Content {
+			if result, ok := findYAMLNodePointer(item, line, column, pointer); ok {
+				return result, true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			keyNode := node.Content[i-1]
+			itemPointer := appendJSONPointer(pointer, keyNode.Value)
+
+			if keyNode.Line == line && keyNode.Column == column {
+				return itemPointer, true
+			}
+
+			if result, ok := findYAMLNodePointer(node.Content[i], line, column, itemPointer); ok {
+				return result, true
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if result, ok := findYAMLNodePointer(item, line, column, appendJSONPointer(pointer, strconv.Itoa(i))); ok {
+				return result, true
+			}
+		}
+	default:
+	}
+
+	if node.Line == line && node.Column == column {
+		return pointer, true
+	}
+
+	return "", false
+}
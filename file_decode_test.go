@@ -0,0 +1,400 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/relychan/goutils/httperror"
+)
+
+type decodeTestConfig struct {
+	Name   string `json:"name" yaml:"name"`
+	Server struct {
+		Port int `json:"port" yaml:"port"`
+	} `json:"server" yaml:"server"`
+	Items []struct {
+		Weight int `json:"weight" yaml:"weight"`
+	} `json:"items" yaml:"items"`
+}
+
+func TestConfigDecodeError(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		URL      string
+		Multi    bool
+		Options  []DownloadFileOption
+		Expected ConfigDecodeError
+	}{
+		{
+			Name: "json_syntax",
+			URL:  "data:application/json," + url.PathEscape("{\n  \"name\": \"app\",\n  \"server\": {\"port\": }\n}"),
+			Expected: ConfigDecodeError{
+				Line:   3,
+				Column: 22,
+			},
+		},
+		{
+			Name: "json_type",
+			URL:  "data:application/json," + url.PathEscape("{\n  \"name\": \"app\",\n  \"server\": {\"port\": \"80\"}\n}"),
+			Expected: ConfigDecodeError{
+				Line:    3,
+				Column:  25,
+				Pointer: "/server/port",
+			},
+		},
+		{
+			Name:  "json_multi_documents",
+			URL:   "data:application/json," + url.PathEscape("{\"name\": \"a\"}\n{\"name\": 1}"),
+			Multi: true,
+			Expected: ConfigDecodeError{
+				DocumentIndex: 1,
+				Line:          2,
+				Column:        10,
+				Pointer:       "/name",
+			},
+		},
+		{
+			Name:    "json_transformed",
+			URL:     "data:application/json," + url.PathEscape(`{"server": {"port": "${PORT}"}}`),
+			Options: []DownloadFileOption{DownloadFileWithEnvExpansion()},
+			Expected: ConfigDecodeError{
+				Pointer: "/server/port",
+			},
+		},
+		{
+			Name: "yaml_syntax",
+			URL:  "data:application/yaml," + url.PathEscape("name: app\nserver:\n  port: [80\n"),
+			Expected: ConfigDecodeError{
+				Line:   4,
+				Column: 1,
+			},
+		},
+		{
+			Name: "yaml_type",
+			URL:  "data:application/yaml," + url.PathEscape("name: app\nitems:\n  - weight: 1\n  - weight: heavy\n"),
+			Expected: ConfigDecodeError{
+				Line:    4,
+				Column:  13,
+				Pointer: "/items/1/weight",
+			},
+		},
+		{
+			Name:  "yaml_multi_documents",
+			URL:   "data:application/yaml," + url.PathEscape("name: a\n---\nserver:\n  port: abc\n"),
+			Multi: true,
+			Expected: ConfigDecodeError{
+				DocumentIndex: 1,
+				Line:          4,
+				Column:        9,
+				Pointer:       "/server/port",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var err error
+
+			if tc.Multi {
+				_, err = ReadMultiFromJSONOrYAMLFile[decodeTestConfig](context.Background(), tc.URL, tc.Options...)
+			} else {
+				_, err = ReadJSONOrYAMLFile[decodeTestConfig](context.Background(), tc.URL, tc.Options...)
+			}
+
+			var decodeErr ConfigDecodeError
+
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("expected ConfigDecodeError, got: %v", err)
+			}
+
+			if decodeErr.FilePath != tc.URL || decodeErr.DocumentIndex != tc.Expected.DocumentIndex ||
+				decodeErr.Line != tc.Expected.Line || decodeErr.Column != tc.Expected.Column ||
+				decodeErr.Pointer != tc.Expected.Pointer {
+				t.Fatalf(
+					"expected (%d, %d:%d, %s), got: (%d, %d:%d, %s) %s",
+					tc.Expected.DocumentIndex, tc.Expected.Line, tc.Expected.Column, tc.Expected.Pointer,
+					decodeErr.DocumentIndex, decodeErr.Line, decodeErr.Column, decodeErr.Pointer, decodeErr.FilePath,
+				)
+			}
+
+			if decodeErr.Unwrap() == nil {
+				t.Fatal("expected the underlying error")
+			}
+		})
+	}
+}
+
+func TestConfigDecodeError_ValidationError(t *testing.T) {
+	err := ConfigDecodeError{
+		FilePath:      "config.yaml",
+		DocumentIndex: 1,
+		Line:          4,
+		Column:        9,
+		Pointer:       "/server/port",
+		Err:           errors.New("invalid port"),
+	}
+
+	if err.Error() != "failed to decode config.yaml document 1 at line 4, column 9 (/server/port): invalid port" {
+		t.Fatalf("unexpected error message: %s", err.Error())
+	}
+
+	expected := httperror.ValidationError{
+		Detail:  "invalid port at line 4, column 9 (/server/port)",
+		Pointer: "/server/port",
+	}
+
+	if err.ValidationError() != expected {
+		t.Fatalf("expected %+v, got: %+v", expected, err.ValidationError())
+	}
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	reader io.Reader
+	count  int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += n
+
+	return n, err
+}
+
+func TestLoadMultiJSONDocumentStreamSeq(t *testing.T) {
+	var sb strings.Builder
+
+	for i := range 10000 {
+		fmt.Fprintf(&sb, "{\"name\": \"item-%d\"}\n", i)
+	}
+
+	input := sb.String()
+
+	t.Run("all", func(t *testing.T) {
+		count := 0
+
+		for result, err := range LoadMultiJSONDocumentStreamSeq[decodeTestConfig](
+			context.Background(),
+			strings.NewReader(input),
+		) {
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			if result.Name != fmt.Sprintf("item-%d", count) {
+				t.Fatalf("unexpected document %d: %+v", count, result)
+			}
+
+			count++
+		}
+
+		if count != 10000 {
+			t.Fatalf("expected 10000 documents, got: %d", count)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		reader := &countingReader{reader: strings.NewReader(input)}
+		count := 0
+
+		for _, err := range LoadMultiJSONDocumentStreamSeq[decodeTestConfig](context.Background(), reader) {
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			count++
+
+			if count == 2 {
+				break
+			}
+		}
+
+		if count != 2 || reader.count >= len(input) {
+			t.Fatalf("expected to stop early, got %d documents and %d bytes read", count, reader.count)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		count := 0
+
+		var lastErr error
+
+		for _, err := range LoadMultiJSONDocumentStreamSeq[decodeTestConfig](ctx, strings.NewReader(input)) {
+			if err != nil {
+				lastErr = err
+
+				continue
+			}
+
+			count++
+
+			if count == 3 {
+				cancel()
+			}
+		}
+
+		if count != 3 || !errors.Is(lastErr, context.Canceled) {
+			t.Fatalf("expected context.Canceled after 3 documents, got %d documents, %v", count, lastErr)
+		}
+	})
+
+	t.Run("error_location", func(t *testing.T) {
+		var lastErr error
+
+		for _, err := range LoadMultiJSONDocumentStreamSeq[decodeTestConfig](
+			context.Background(),
+			strings.NewReader(input+"{\"name\": 1}\n"),
+		) {
+			lastErr = err
+		}
+
+		var decodeErr ConfigDecodeError
+
+		if !errors.As(lastErr, &decodeErr) || decodeErr.DocumentIndex != 10000 ||
+			decodeErr.Line != 10001 || decodeErr.Column != 10 || decodeErr.Pointer != "/name" {
+			t.Fatalf("expected the error at document 10000, line 10001, column 10, got: %v", lastErr)
+		}
+	})
+}
+
+func TestLoadMultiJSONDocumentStreamSeq_SingleLine(t *testing.T) {
+	var sb strings.Builder
+
+	for i := range 1000 {
+		fmt.Fprintf(&sb, "{\"name\": \"item-%d\"} ", i)
+	}
+
+	prefix := sb.String()
+
+	var lastErr error
+
+	count := 0
+
+	for _, err := range LoadMultiJSONDocumentStreamSeq[decodeTestConfig](
+		context.Background(),
+		strings.NewReader(prefix+"{\"name\": 1}"),
+	) {
+		if err == nil {
+			count++
+		}
+
+		lastErr = err
+	}
+
+	var decodeErr ConfigDecodeError
+
+	if count != 1000 || !errors.As(lastErr, &decodeErr) || decodeErr.DocumentIndex != 1000 ||
+		decodeErr.Line != 1 || decodeErr.Column != len(prefix)+10 || decodeErr.Pointer != "/name" {
+		t.Fatalf("expected the error at document 1000, line 1, column %d, got %d documents, %v", len(prefix)+10, count, lastErr)
+	}
+}
+
+func TestJSONStreamContent_Discard(t *testing.T) {
+	content := &jsonStreamContent{}
+	input := "{\"a\": 1} {\"a\": 2}\n  {\"a\": 3} {\"a\": x}"
+
+	_, _ = content.Write([]byte(input))
+
+	for _, offset := range []int64{8, 17, 28} {
+		content.discard(offset)
+
+		if content.buffer.Len() != len(input)-int(offset) {
+			t.Fatalf("expected %d recorded bytes after discarding %d, got: %d", len(input)-int(offset), offset, content.buffer.Len())
+		}
+	}
+
+	// The offset of the decoder after it read the x byte.
+	line, column := content.location(int64(len(input) - 1))
+	if line != 2 || column != 18 {
+		t.Fatalf("expected 2:18, got: %d:%d", line, column)
+	}
+
+	content.discard(int64(len(input) - 2))
+
+	line, column = content.location(int64(len(input) - 1))
+	if line != 2 || column != 18 {
+		t.Fatalf("expected 2:18 after discarding the line, got: %d:%d", line, column)
+	}
+}
+
+func TestLoadMultiYAMLDocumentStreamSeq(t *testing.T) {
+	input := "name: a\n---\nname: b\n---\nname: [c\n"
+
+	var names []string
+
+	var lastErr error
+
+	for result, err := range LoadMultiYAMLDocumentStreamSeq[decodeTestConfig](
+		context.Background(),
+		strings.NewReader(input),
+	) {
+		if err != nil {
+			lastErr = err
+
+			continue
+		}
+
+		names = append(names, result.Name)
+	}
+
+	if strings.Join(names, ",") != "a,b" {
+		t.Fatalf("expected documents a and b, got: %v", names)
+	}
+
+	var decodeErr ConfigDecodeError
+
+	if !errors.As(lastErr, &decodeErr) || decodeErr.DocumentIndex != 2 {
+		t.Fatalf("expected the error at document 2, got: %v", lastErr)
+	}
+}
+
+func TestReadMultiFromJSONOrYAMLFileSeq(t *testing.T) {
+	var names []string
+
+	for result, err := range ReadMultiFromJSONOrYAMLFileSeq[decodeTestConfig](
+		context.Background(),
+		"data:application/yaml,"+url.PathEscape("name: a\n---\nname: b\n---\nname: c\n"),
+	) {
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		names = append(names, result.Name)
+
+		if len(names) == 2 {
+			break
+		}
+	}
+
+	if strings.Join(names, ",") != "a,b" {
+		t.Fatalf("expected documents a and b, got: %v", names)
+	}
+
+	for _, err := range ReadMultiFromJSONOrYAMLFileSeq[decodeTestConfig](context.Background(), "not-found.yaml") {
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/relychan/goutils/httpheader"
+)
+
+const (
+	// FileFormatJSONC represents the JSON format with comments and trailing commas.
+	FileFormatJSONC FileFormat = "jsonc"
+	// FileFormatTOML represents the TOML format.
+	FileFormatTOML FileFormat = "toml"
+)
+
+var (
+	errFileDecoderRequired         = errors.New("file decoder must have a ToJSON function and at least one extension")
+	errInvalidFileDecoderExtension = errors.New("file decoder extension must start with a dot and must not be a JSON or YAML extension")
+	errInvalidFileDecoderMediaType = errors.New("file decoder media type must not be empty, JSON or YAML")
+)
+
+// FileDecoder describes how to decode a config file format other than JSON and YAML.
+// Documents are converted to a JSON stream, so they are decoded with the same options as JSON documents,
+// e.g. the strict mode, env expansion and includes.
+type FileDecoder struct {
+	// Extensions of the format with the leading dot, e.g. ".toml".
+	// The first extension is used when the format is detected from the media type.
+	Extensions []string
+	// Media types of the format, e.g. "application/toml".
+	MediaTypes []string
+	// ToJSON converts the documents of the reader to a JSON stream.
+	// Errors with a [ConfigDecodeError] keep its line and column.
+	ToJSON func(reader io.Reader) (io.Reader, error)
+	// PreservesOffsets reports whether every byte of the JSON stream is at the same offset as in the file,
+	// so decoding errors are located at the line and column of the file.
+	PreservesOffsets bool
+}
+
+type fileDecoderRegistry struct {
+	mu          sync.RWMutex
+	byExtension map[string]*FileDecoder
+	byMediaType map[string]*FileDecoder
+}
+
+var (
+	jsoncFileDecoder = &FileDecoder{
+		Extensions:       []string{".jsonc"},
+		MediaTypes:       []string{"application/jsonc"},
+		ToJSON:           jsoncToJSON,
+		PreservesOffsets: true,
+	}
+	tomlFileDecoder = &FileDecoder{
+		Extensions: []string{".toml"},
+		MediaTypes: []string{"application/toml"},
+		ToJSON:     tomlToJSON,
+	}
+	fileDecoders = &fileDecoderRegistry{
+		byExtension: map[string]*FileDecoder{
+			".jsonc": jsoncFileDecoder,
+			".toml":  tomlFileDecoder,
+		},
+		byMediaType: map[string]*FileDecoder{
+			"application/jsonc": jsoncFileDecoder,
+			"application/toml":  tomlFileDecoder,
+		},
+	}
+)
+
+// RegisterFileDecoder registers the decoder of a config format by its extensions and media types,
+// so [ReadJSONOrYAMLFile] and other file loaders can decode files of the format.
+// A later registration replaces the previous decoder of the same extension or media type.
+// JSON and YAML are always decoded natively and can't be replaced, so their extensions and media types
+// are rejected, as well as extensions without the leading dot.
+func RegisterFileDecoder(decoder FileDecoder) error {
+	err := validateFileDecoder(&decoder)
+	if err != nil {
+		return err
+	}
+
+	fileDecoders.mu.Lock()
+	defer fileDecoders.mu.Unlock()
+
+	for _, ext := range decoder.Extensions {
+		fileDecoders.byExtension[strings.ToLower(ext)] = &decoder
+	}
+
+	for _, mediaType := range decoder.MediaTypes {
+		fileDecoders.byMediaType[strings.ToLower(httpheader.ExtractBaseMediaType(mediaType))] = &decoder
+	}
+
+	return nil
+}
+
+// validateFileDecoder checks that every extension and media type of the decoder can be used.
+func validateFileDecoder(decoder *FileDecoder) error {
+	if decoder.ToJSON == nil || len(decoder.Extensions) == 0 {
+		return errFileDecoderRequired
+	}
+
+	for _, ext := range decoder.Extensions {
+		if len(ext) < 2 || ext[0] != '.' || isNativeFileExtension(strings.ToLower(ext)) {
+			return fmt.Errorf("%w: %q", errInvalidFileDecoderExtension, ext)
+		}
+	}
+
+	for _, mediaType := range decoder.MediaTypes {
+		if httpheader.ExtractBaseMediaType(mediaType) == "" ||
+			httpheader.IsContentTypeJSON(mediaType) || httpheader.IsContentTypeYAML(mediaType) {
+			return fmt.Errorf("%w: %q", errInvalidFileDecoderMediaType, mediaType)
+		}
+	}
+
+	return nil
+}
+
+// lookupFileDecoder returns the registered decoder of the extension.
+func lookupFileDecoder(ext string) *FileDecoder {
+	fileDecoders.mu.RLock()
+	defer fileDecoders.mu.RUnlock()
+
+	return fileDecoders.byExtension[ext]
+}
+
+// lookupFileDecoderExtension returns the extension of the registered decoder of the content type.
+func lookupFileDecoderExtension(contentType string) (string, bool) {
+	fileDecoders.mu.RLock()
+	defer fileDecoders.mu.RUnlock()
+
+	decoder, ok := fileDecoders.byMediaType[strings.ToLower(httpheader.ExtractBaseMediaType(contentType))]
+	if !ok {
+		return "", false
+	}
+
+	return strings.ToLower(decoder.Extensions[0]), true
+}
+
+// convertToJSON converts the content of a registered format to a JSON stream.
+func (fd *FileDecoder) convertToJSON(reader io.Reader, doc document) (io.Reader, error) {
+	result, err := fd.ToJSON(reader)
+	if err == nil {
+		return result, nil
+	}
+
+	var decodeErr ConfigDecodeError
+
+	if !errors.As(err, &decodeErr) {
+		decodeErr = ConfigDecodeError{Err: err}
+	}
+
+	decodeErr.FilePath = doc.FilePath
+	decodeErr.DocumentIndex = doc.Index
+
+	return nil, decodeErr
+}
+
+// jsoncToJSON converts JSON with comments and trailing commas to JSON.
+// Comments and trailing commas are replaced with spaces, so the offsets of the content are preserved.
+func jsoncToJSON(reader io.Reader) (io.Reader, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// The position of the last comma that may be trailing.
+	comma := -1
+
+	for i := 0; i < len(content); i++ {
+		switch content[i] {
+		case '"':
+			comma = -1
+			i = skipJSONString(content, i)
+		case '/':
+			if i+1 >= len(content) {
+				continue
+			}
+
+			switch content[i+1] {
+			case '/':
+				i = blankJSONComment(content, i, "\n")
+			case '*':
+				i = blankJSONComment(content, i, "*/")
+			default:
+				comma = -1
+			}
+		case ',':
+			comma = i
+		case '}', ']':
+			if comma >= 0 {
+				content[comma] = ' '
+			}
+
+			comma = -1
+		case ' ', '\t', '\r', '\n':
+		default:
+			comma = -1
+		}
+	}
+
+	return bytes.NewReader(content), nil
+}
+
+// skipJSONString returns the position of the closing quote of the string that starts at the position.
+func skipJSONString(content []byte, start int) int {
+	for i := start + 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		default:
+		}
+	}
+
+	return len(content)
+}
+
+// blankJSONComment replaces the comment that starts at the position with spaces until the terminator,
+// and returns the position of the last byte of the comment. New lines are kept to preserve line numbers.
+func blankJSONComment(content []byte, start int, terminator string) int {
+	end := bytes.Index(content[start+2:], []byte(terminator))
+	if end < 0 {
+		end = len(content)
+	} else {
+		end += start + 2
+
+		if terminator != "\n" {
+			end += len(terminator)
+		}
+	}
+
+	for i := start; i < end; i++ {
+		if content[i] != '\n' && content[i] != '\r' {
+			content[i] = ' '
+		}
+	}
+
+	return end - 1
+}
+
+// tomlToJSON converts a TOML document to JSON.
+func tomlToJSON(reader io.Reader) (io.Reader, error) {
+	var value map[string]any
+
+	_, err := toml.NewDecoder(reader).Decode(&value)
+	if err != nil {
+		var parseErr toml.ParseError
+
+		if errors.As(err, &parseErr) {
+			return nil, ConfigDecodeError{
+				Line:   parseErr.Position.Line,
+				Column: parseErr.Position.Col,
+				Err:    errors.New(parseErr.Message),
+			}
+		}
+
+		return nil, err
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(content), nil
+}
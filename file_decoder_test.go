@@ -0,0 +1,240 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type decoderTestConfig struct {
+	Name     string   `json:"name"     yaml:"name"`
+	Features []string `json:"features" yaml:"features"`
+	Server   struct {
+		Host string `json:"host" yaml:"host"`
+		Port int    `json:"port" yaml:"port"`
+	} `json:"server" yaml:"server"`
+}
+
+func TestReadJSONOrYAMLFile_RegisteredDecoders(t *testing.T) {
+	for _, filePath := range []string{"testdata/decoder/app.toml", "testdata/decoder/app.jsonc"} {
+		t.Run(filePath, func(t *testing.T) {
+			result, err := ReadJSONOrYAMLFile[decoderTestConfig](
+				context.Background(),
+				filePath,
+				DownloadFileWithStrict(),
+			)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			if result.Name != "app" || result.Server.Host != "0.0.0.0" || result.Server.Port != 8080 ||
+				!reflect.DeepEqual(result.Features, []string{"auth", "metrics"}) {
+				t.Fatalf("unexpected result: %+v", result)
+			}
+		})
+	}
+
+	t.Run("media_type", func(t *testing.T) {
+		content, err := os.ReadFile("testdata/decoder/app.toml")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/toml; charset=utf-8")
+			_, _ = w.Write(content)
+		}))
+		defer server.Close()
+
+		result, err := ReadJSONOrYAMLFile[decoderTestConfig](context.Background(), server.URL+"/config")
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if result.Server.Port != 8080 {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		result, err := ReadMergedJSONOrYAMLFiles[decoderTestConfig](
+			context.Background(),
+			[]string{"testdata/decoder/app.toml", "testdata/decoder/app.jsonc", "testdata/merge/local.yaml"},
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if result.Value.Server.Port != 8080 || result.Sources["/server/port"] != "testdata/decoder/app.jsonc" {
+			t.Fatalf("unexpected result: %+v, %v", result.Value, result.Sources)
+		}
+	})
+}
+
+func TestRegisteredDecoders_Errors(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		URL      string
+		Expected ConfigDecodeError
+	}{
+		{
+			Name:     "toml_syntax",
+			URL:      "data:application/toml," + url.PathEscape("name = \"app\"\n[server]\nport = \n"),
+			Expected: ConfigDecodeError{Line: 3, Column: 8},
+		},
+		{
+			Name: "jsonc_type",
+			URL: "data:application/jsonc," + url.PathEscape(
+				"{\n  // comment\n  \"server\": {\"port\": \"80\", /* x */},\n}",
+			),
+			Expected: ConfigDecodeError{Line: 3, Column: 25, Pointer: "/server/port"},
+		},
+		{
+			Name:     "toml_type",
+			URL:      "data:application/toml," + url.PathEscape("[server]\nport = \"80\"\n"),
+			Expected: ConfigDecodeError{Pointer: "/server/port"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ReadJSONOrYAMLFile[decoderTestConfig](context.Background(), tc.URL)
+
+			var decodeErr ConfigDecodeError
+
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("expected ConfigDecodeError, got: %v", err)
+			}
+
+			if decodeErr.FilePath != tc.URL || decodeErr.Line != tc.Expected.Line ||
+				decodeErr.Column != tc.Expected.Column || decodeErr.Pointer != tc.Expected.Pointer {
+				t.Fatalf(
+					"expected (%d:%d, %s), got: (%d:%d, %s) %s",
+					tc.Expected.Line, tc.Expected.Column, tc.Expected.Pointer,
+					decodeErr.Line, decodeErr.Column, decodeErr.Pointer, err,
+				)
+			}
+		})
+	}
+}
+
+func TestRegisterFileDecoder(t *testing.T) {
+	err := RegisterFileDecoder(FileDecoder{Extensions: []string{".kv"}})
+	if !errors.Is(err, errFileDecoderRequired) {
+		t.Fatalf("expected errFileDecoderRequired, got: %v", err)
+	}
+
+	for _, decoder := range []struct {
+		FileDecoder
+		Err error
+	}{
+		{FileDecoder: FileDecoder{Extensions: []string{"kv"}}, Err: errInvalidFileDecoderExtension},
+		{FileDecoder: FileDecoder{Extensions: []string{"."}}, Err: errInvalidFileDecoderExtension},
+		{FileDecoder: FileDecoder{Extensions: []string{".kv", ".JSON"}}, Err: errInvalidFileDecoderExtension},
+		{FileDecoder: FileDecoder{Extensions: []string{".yml"}}, Err: errInvalidFileDecoderExtension},
+		{FileDecoder: FileDecoder{Extensions: []string{".kv"}, MediaTypes: []string{" "}}, Err: errInvalidFileDecoderMediaType},
+		{FileDecoder: FileDecoder{Extensions: []string{".kv"}, MediaTypes: []string{"application/vnd.kv+json"}}, Err: errInvalidFileDecoderMediaType},
+		{FileDecoder: FileDecoder{Extensions: []string{".kv"}, MediaTypes: []string{"text/x-yaml; charset=utf-8"}}, Err: errInvalidFileDecoderMediaType},
+	} {
+		decoder.ToJSON = jsoncToJSON
+
+		err = RegisterFileDecoder(decoder.FileDecoder)
+		if !errors.Is(err, decoder.Err) {
+			t.Errorf("%v: expected %v, got: %v", decoder.FileDecoder, decoder.Err, err)
+		}
+	}
+
+	if lookupFileDecoder(".kv") != nil {
+		t.Fatal("expected invalid decoders not to be registered")
+	}
+
+	// A simple format of key=value lines.
+	err = RegisterFileDecoder(FileDecoder{
+		Extensions: []string{".kv"},
+		MediaTypes: []string{"text/x-kv"},
+		ToJSON: func(reader io.Reader) (io.Reader, error) {
+			value := map[string]string{}
+			scanner := bufio.NewScanner(reader)
+
+			for scanner.Scan() {
+				key, item, _ := strings.Cut(scanner.Text(), "=")
+				value[strings.TrimSpace(key)] = strings.TrimSpace(item)
+			}
+
+			content, err := json.Marshal(value)
+
+			return bytes.NewReader(content), err
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	result, err := ReadJSONOrYAMLFile[map[string]string](
+		context.Background(),
+		"data:text/x-kv,"+url.PathEscape("name = app\nregion=${REGION:-us}\n"),
+		DownloadFileWithEnvLookup(func(string) (string, bool) { return "", false }),
+	)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	if !reflect.DeepEqual(*result, map[string]string{"name": "app", "region": "us"}) {
+		t.Fatalf("unexpected result: %v", *result)
+	}
+}
+
+func TestJSONCToJSON(t *testing.T) {
+	input := "{\"a\": \"// not a comment\", \"b\": \"x,\\\"]\", // comment\n\"c\": [1, 2 /* , */,],\r\n}"
+
+	reader, err := jsoncToJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(content) != len(input) {
+		t.Fatalf("expected the same length, got: %q", content)
+	}
+
+	var value map[string]any
+
+	err = json.Unmarshal(content, &value)
+	if err != nil {
+		t.Fatalf("expected valid JSON, got: %s\n%s", err, content)
+	}
+
+	expected := map[string]any{"a": "// not a comment", "b": "x,\"]", "c": []any{float64(1), float64(2)}}
+
+	if !reflect.DeepEqual(value, expected) {
+		t.Fatalf("expected %v, got: %v", expected, value)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// EnvLookupFunc abstracts a function to look up the value of an environment variable.
+type EnvLookupFunc func(name string) (string, bool)
+
+// MissingEnvError occurs when a required variable of a ${VAR:?message} expression is unset or empty.
+type MissingEnvError struct {
+	// The path of the document file.
+	FilePath string
+	// The index of the document in a multi-document file.
+	DocumentIndex int
+	// The JSON Pointer of the value in the document.
+	Pointer string
+	// The name of the variable.
+	Name string
+	// The message of the expression.
+	Message string
+}
+
+// Error implements the error interface for MissingEnvError.
+func (e MissingEnvError) Error() string {
+	pointer := e.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+
+	message := e.Message
+	if message == "" {
+		message = "required variable is not set"
+	}
+
+	return fmt.Sprintf("%s: %s at %s of %s", e.Name, message, pointer, e.FilePath)
+}
+
+type envExpander struct {
+	lookup   EnvLookupFunc
+	document document
+}
+
+func (ee envExpander) expandJSONValue(value any, pointer string) (any, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return ee.expandString(typedValue, pointer)
+	case map[string]any:
+		for key, item := range typedValue {
+			newItem, err := ee.expandJSONValue(item, appendJSONPointer(pointer, key))
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[key] = newItem
+		}
+
+		return typedValue, nil
+	case []any:
+		for i, item := range typedValue {
+			newItem, err := ee.expandJSONValue(item, appendJSONPointer(pointer, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[i] = newItem
+		}
+
+		return typedValue, nil
+	default:
+		return value, nil
+	}
+}
+
+func (ee envExpander) expandYAMLNode(node *yaml.Node, pointer string) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, item := range node.Content {
+			err := ee.expandYAMLNode(item, pointer)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		// Only expand values. Keys are kept as is.
+		for i := 1; i < len(node.Content); i += 2 {
+			err := ee.expandYAMLNode(node.Content[i], appendJSONPointer(pointer, node.Content[i-1].Value))
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			err := ee.expandYAMLNode(item, appendJSONPointer(pointer, strconv.Itoa(i)))
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		value, err := ee.expandString(node.Value, pointer)
+		if err != nil {
+			return err
+		}
+
+		if value == node.Value {
+			return nil
+		}
+
+		node.Value = value
+
+		// Resolve the type of plain scalars again from the expanded value, e.g. port: ${PORT}.
+		if node.Style&(yaml.TaggedStyle|yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle|
+			yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			node.Tag = ""
+		}
+	default:
+		// Aliases point to anchored nodes that are already expanded.
+	}
+
+	return nil
+}
+
+// expandString expands all ${...} expressions of the string.
+func (ee envExpander) expandString(value string, pointer string) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	var builder strings.Builder
+
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			builder.WriteString(value)
+
+			break
+		}
+
+		if start > 0 && value[start-1] == '$' {
+			// Escaped $${.
+			builder.WriteString(value[:start-1])
+			builder.WriteString("${")
+
+			value = value[start+2:]
+
+			continue
+		}
+
+		end := findEnvExpressionEnd(value, start+2)
+		if end < 0 {
+			builder.WriteString(value)
+
+			break
+		}
+
+		builder.WriteString(value[:start])
+
+		result, err := ee.expandExpression(value[start:end+1], value[start+2:end], pointer)
+		if err != nil {
+			return "", err
+		}
+
+		builder.WriteString(result)
+
+		value = value[end+1:]
+	}
+
+	return builder.String(), nil
+}
+
+func (ee envExpander) expandExpression(raw string, expression string, pointer string) (string, error) {
+	name, operator, argument := expression, "", ""
+
+	if index := strings.Index(expression, ":"); index >= 0 && index+1 < len(expression) {
+		name, operator, argument = expression[:index], expression[index:index+2], expression[index+2:]
+	}
+
+	if !isEnvName(name) || (operator != "" && operator != ":-" && operator != ":?") {
+		return raw, nil
+	}
+
+	value, _ := ee.lookup(name)
+	if value != "" {
+		return value, nil
+	}
+
+	switch operator {
+	case ":-":
+		return ee.expandString(argument, pointer)
+	case ":?":
+		return "", MissingEnvError{
+			FilePath:      ee.document.FilePath,
+			DocumentIndex: ee.document.Index,
+			Pointer:       pointer,
+			Name:          name,
+			Message:       argument,
+		}
+	default:
+		return "", nil
+	}
+}
+
+// findEnvExpressionEnd returns the index of the closing brace of the expression, including nested ones.
+func findEnvExpressionEnd(value string, start int) int {
+	depth := 1
+
+	for i := start; i < len(value); i++ {
+		switch value[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+
+			if depth == 0 {
+				return i
+			}
+		default:
+		}
+	}
+
+	return -1
+}
+
+func isEnvName(name string) bool {
+	if name == "" || IsDigit(name[0]) {
+		return false
+	}
+
+	for _, c := range []byte(name) {
+		if !IsLowerAlphabet(c) && !IsUpperAlphabet(c) && !IsDigit(c) && c != '_' {
+			return false
+		}
+	}
+
+	return true
+}
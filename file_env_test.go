@@ -0,0 +1,178 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type envTestConfig struct {
+	Host    string            `json:"host"    yaml:"host"`
+	Port    int               `json:"port"    yaml:"port"`
+	Debug   bool              `json:"debug"   yaml:"debug"`
+	Quoted  string            `json:"quoted"  yaml:"quoted"`
+	Labels  map[string]string `json:"labels"  yaml:"labels"`
+	Aliases []string          `json:"aliases" yaml:"aliases"`
+}
+
+func TestDownloadFileWithEnvLookup(t *testing.T) {
+	env := map[string]string{
+		"HOST":  "example.com",
+		"PORT":  "8080",
+		"DEBUG": "true",
+		"EMPTY": "",
+	}
+
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+
+		return value, ok
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		content := `
+host: ${HOST}
+port: ${PORT}
+debug: ${DEBUG}
+quoted: "${PORT}"
+labels:
+  ${HOST}: ${MISSING:-${HOST}-default}
+  escaped: $${HOST}
+  template: ${a.b}
+aliases:
+  - ${EMPTY:-fallback}
+  - prefix-${UNSET}-suffix
+`
+
+		result, err := ReadJSONOrYAMLFile[envTestConfig](
+			context.Background(),
+			"data:application/yaml,"+url.PathEscape(content),
+			DownloadFileWithEnvLookup(lookup),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		expected := envTestConfig{
+			Host:   "example.com",
+			Port:   8080,
+			Debug:  true,
+			Quoted: "8080",
+			Labels: map[string]string{
+				"${HOST}":  "example.com-default",
+				"escaped":  "${HOST}",
+				"template": "${a.b}",
+			},
+			Aliases: []string{"fallback", "prefix--suffix"},
+		}
+
+		if !reflect.DeepEqual(*result, expected) {
+			t.Fatalf("expected %+v, got: %+v", expected, *result)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		content := `{"host": "${HOST}", "port": 8080, "labels": {"${HOST}": "${HOST:-x}"}, "aliases": ["${PORT}"]}`
+
+		result, err := ReadJSONOrYAMLFile[envTestConfig](
+			context.Background(),
+			"data:application/json,"+url.PathEscape(content),
+			DownloadFileWithEnvLookup(lookup),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		expected := envTestConfig{
+			Host:    "example.com",
+			Port:    8080,
+			Labels:  map[string]string{"${HOST}": "example.com"},
+			Aliases: []string{"8080"},
+		}
+
+		if !reflect.DeepEqual(*result, expected) {
+			t.Fatalf("expected %+v, got: %+v", expected, *result)
+		}
+	})
+
+	t.Run("disabled_by_default", func(t *testing.T) {
+		result, err := ReadJSONOrYAMLFile[envTestConfig](
+			context.Background(),
+			`data:application/json,{"host": "${HOST}"}`,
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if result.Host != "${HOST}" {
+			t.Fatalf("expected ${HOST}, got: %s", result.Host)
+		}
+	})
+
+	t.Run("missing_required", func(t *testing.T) {
+		_, err := ReadMultiFromJSONOrYAMLFile[envTestConfig](
+			context.Background(),
+			"testdata/env/multi.yaml",
+			DownloadFileWithEnvLookup(lookup),
+		)
+
+		var missingErr MissingEnvError
+
+		if !errors.As(err, &missingErr) {
+			t.Fatalf("expected MissingEnvError, got: %v", err)
+		}
+
+		expected := MissingEnvError{
+			FilePath:      "testdata/env/multi.yaml",
+			DocumentIndex: 1,
+			Pointer:       "/labels/api~1token",
+			Name:          "API_TOKEN",
+			Message:       "the API token is required",
+		}
+
+		if missingErr != expected {
+			t.Fatalf("expected %+v, got: %+v", expected, missingErr)
+		}
+
+		if missingErr.Error() != "API_TOKEN: the API token is required at /labels/api~1token of testdata/env/multi.yaml" {
+			t.Fatalf("unexpected error message: %s", missingErr.Error())
+		}
+	})
+
+	t.Run("multi_documents", func(t *testing.T) {
+		results, err := ReadMultiFromJSONOrYAMLFile[envTestConfig](
+			context.Background(),
+			"testdata/env/multi.yaml",
+			DownloadFileWithEnvLookup(func(name string) (string, bool) {
+				if name == "API_TOKEN" {
+					return "secret", true
+				}
+
+				return lookup(name)
+			}),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if len(results) != 2 || results[0].Port != 8080 || results[1].Labels["api/token"] != "secret" {
+			t.Fatalf("unexpected results: %+v", results)
+		}
+	})
+}
@@ -0,0 +1,316 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// JSONRefKey is the key of a JSON object that references another file.
+const JSONRefKey = "$ref"
+
+// maxIncludeDepth is the maximum depth of nested includes.
+const maxIncludeDepth = 32
+
+var (
+	errIncludeDepthExceeded  = fmt.Errorf("include depth exceeds the limit of %d", maxIncludeDepth)
+	errRelativeIncludePath   = errors.New("relative include path can't be resolved from the parent")
+	errIncludeFragment       = errors.New("include reference must not have a fragment")
+	errInvalidIncludeRefNode = errors.New("include tag must be a scalar file path")
+	errRemoteLocalInclude    = errors.New("remote document must not include files of other schemes than http and https")
+)
+
+// IncludeCycleError occurs when a document includes itself directly or indirectly.
+type IncludeCycleError struct {
+	// The include chain from the root document to the repeated file.
+	Chain []string
+}
+
+// Error implements the error interface for IncludeCycleError.
+func (e IncludeCycleError) Error() string {
+	return "include cycle detected: " + strings.Join(e.Chain, " -> ")
+}
+
+type includeResolver struct {
+	options  *downloadFileOptions
+	document document
+}
+
+func (ir includeResolver) resolveYAMLNode(ctx context.Context, node *yaml.Node, pointer string) error {
+	if node.Tag == YAMLIncludeTag && node.Kind != yaml.ScalarNode {
+		return ir.wrapError(node.Value, pointer, errInvalidIncludeRefNode)
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, item := range node.Content {
+			err := ir.resolveYAMLNode(ctx, item, pointer)
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			err := ir.resolveYAMLNode(ctx, node.Content[i], appendJSONPointer(pointer, node.Content[i-1].Value))
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			err := ir.resolveYAMLNode(ctx, item, appendJSONPointer(pointer, strconv.Itoa(i)))
+			if err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if node.Tag != YAMLIncludeTag {
+			return nil
+		}
+
+		included, err := ir.includeYAMLNode(ctx, node.Value)
+		if err != nil {
+			return ir.wrapError(node.Value, pointer, err)
+		}
+
+		// Replace the node in place, so aliases of the node see the included content.
+		*node = *included
+	default:
+		// Aliases point to anchored nodes that are already resolved.
+	}
+
+	return nil
+}
+
+func (ir includeResolver) resolveJSONValue(ctx context.Context, value any, pointer string) (any, error) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		if ref, ok := typedValue[JSONRefKey].(string); ok && len(typedValue) == 1 && !strings.HasPrefix(ref, "#") {
+			included, err := ir.includeValue(ctx, ref)
+			if err != nil {
+				return nil, ir.wrapError(ref, pointer, err)
+			}
+
+			return included, nil
+		}
+
+		for key, item := range typedValue {
+			newItem, err := ir.resolveJSONValue(ctx, item, appendJSONPointer(pointer, key))
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[key] = newItem
+		}
+
+		return typedValue, nil
+	case []any:
+		for i, item := range typedValue {
+			newItem, err := ir.resolveJSONValue(ctx, item, appendJSONPointer(pointer, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+
+			typedValue[i] = newItem
+		}
+
+		return typedValue, nil
+	default:
+		return value, nil
+	}
+}
+
+// includeYAMLNode loads the first document of the included file as a YAML node.
+func (ir includeResolver) includeYAMLNode(ctx context.Context, ref string) (*yaml.Node, error) {
+	file, ext, doc, err := ir.open(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	defer CatchWarnErrorFunc(file.Close)
+
+	result := &yaml.Node{Kind: yaml.ScalarNode, Tag: YAMLNullTag, Value: "null"}
+
+	switch ext {
+	case ".yaml", ".yml":
+		err = walkYAMLDocuments(ctx, file, doc, ir.options, 1, func(_ document, node *yaml.Node) error {
+			if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+				result = node.Content[0]
+			}
+
+			return nil
+		})
+	default:
+		var value any
+
+		value, err = decodeDocumentValue(ctx, file, doc, ext, ir.options)
+		if err == nil {
+			err = result.Encode(normalizeJSONNumbers(value))
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// includeValue loads the first document of the included file as a generic value.
+func (ir includeResolver) includeValue(ctx context.Context, ref string) (any, error) {
+	file, ext, doc, err := ir.open(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	defer CatchWarnErrorFunc(file.Close)
+
+	return decodeDocumentValue(ctx, file, doc, ext, ir.options)
+}
+
+func (ir includeResolver) open(ctx context.Context, ref string) (io.ReadCloser, string, document, error) {
+	filePath, err := resolveIncludePath(ir.document.FilePath, ref)
+	if err != nil {
+		return nil, "", document{}, err
+	}
+
+	// A remote document must not copy local files or other sources into the result.
+	if !ir.options.RemoteLocalIncludes && isHTTPURL(ir.document.FilePath) && !isHTTPURL(filePath) {
+		return nil, "", document{}, errRemoteLocalInclude
+	}
+
+	chain := append(slices.Clone(ir.document.Parents), ir.document.FilePath)
+
+	if len(chain) >= maxIncludeDepth {
+		return nil, "", document{}, errIncludeDepthExceeded
+	}
+
+	key := includePathKey(filePath)
+
+	if slices.ContainsFunc(chain, func(item string) bool {
+		return includePathKey(item) == key
+	}) {
+		return nil, "", document{}, IncludeCycleError{Chain: append(chain, filePath)}
+	}
+
+	file, ext, err := fileReaderFromPath(ctx, filePath, ir.options)
+	if err != nil {
+		return nil, "", document{}, err
+	}
+
+	return file, ext, document{FilePath: filePath, Parents: chain}, nil
+}
+
+func (ir includeResolver) wrapError(ref string, pointer string, err error) error {
+	var cycleErr IncludeCycleError
+
+	if errors.As(err, &cycleErr) {
+		return err
+	}
+
+	if pointer == "" {
+		pointer = "/"
+	}
+
+	return fmt.Errorf("failed to include %s at %s of %s: %w", ref, pointer, ir.document.FilePath, err)
+}
+
+// resolveIncludePath resolves the path or URL of the include reference relative to the parent file.
+func resolveIncludePath(parent string, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", errFilePathRequired
+	}
+
+	if strings.Contains(ref, "#") {
+		return "", errIncludeFragment
+	}
+
+	if hasURLScheme(ref) {
+		return ref, nil
+	}
+
+	if !hasURLScheme(parent) {
+		if filepath.IsAbs(ref) {
+			return filepath.Clean(ref), nil
+		}
+
+		return filepath.Join(filepath.Dir(parent), ref), nil
+	}
+
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+
+	parentURL, err := url.Parse(parent)
+	if err != nil {
+		return "", err
+	}
+
+	if parentURL.Opaque == "" {
+		return parentURL.ResolveReference(refURL).String(), nil
+	}
+
+	// Opaque URLs don't have a hierarchical path, e.g. "embed:config/app.yaml".
+	// Data URLs don't have a path at all.
+	if strings.EqualFold(parentURL.Scheme, dataURLScheme) {
+		return "", errRelativeIncludePath
+	}
+
+	if path.IsAbs(refURL.Path) {
+		return parentURL.Scheme + ":" + path.Clean(refURL.Path), nil
+	}
+
+	return parentURL.Scheme + ":" + path.Join(path.Dir(parentURL.Opaque), refURL.Path), nil
+}
+
+// hasURLScheme checks if the input starts with the scheme of an HTTP URL or a file source.
+func hasURLScheme(input string) bool {
+	scheme, _, found := strings.Cut(input, ":")
+
+	return found && (isFileSourceScheme(scheme) || slices.ContainsFunc(httpSchemes, func(item string) bool {
+		return strings.EqualFold(item, scheme)
+	}))
+}
+
+// isHTTPURL checks if the input starts with the scheme of an HTTP URL.
+func isHTTPURL(input string) bool {
+	scheme, _, found := strings.Cut(input, ":")
+
+	return found && slices.ContainsFunc(httpSchemes, func(item string) bool {
+		return strings.EqualFold(item, scheme)
+	})
+}
+
+// includePathKey returns the key to compare file paths for cycle detection.
+func includePathKey(filePath string) string {
+	if hasURLScheme(filePath) {
+		return filePath
+	}
+
+	return filepath.Clean(filePath)
+}
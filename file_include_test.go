@@ -0,0 +1,269 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+type includeTestConfig struct {
+	Name   string `json:"name" yaml:"name"`
+	Server struct {
+		Timeout int `json:"timeout" yaml:"timeout"`
+		Port    int `json:"port"    yaml:"port"`
+	} `json:"server" yaml:"server"`
+	Database struct {
+		Host    string            `json:"host"    yaml:"host"`
+		Port    int               `json:"port"    yaml:"port"`
+		Options map[string]string `json:"options" yaml:"options"`
+	} `json:"database" yaml:"database"`
+	Features []string       `json:"features" yaml:"features"`
+	Schema   map[string]any `json:"schema"   yaml:"schema"`
+}
+
+func TestDownloadFileWithIncludes(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		result, err := ReadJSONOrYAMLFile[includeTestConfig](
+			context.Background(),
+			"testdata/include/app.yaml",
+			DownloadFileWithIncludes(),
+			DownloadFileWithEnvLookup(func(string) (string, bool) { return "", false }),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if result.Server.Timeout != 30 || result.Server.Port != 8080 {
+			t.Fatalf("unexpected server: %+v", result.Server)
+		}
+
+		if result.Database.Host != "localhost" || result.Database.Port != 5432 ||
+			result.Database.Options["sslmode"] != "disable" {
+			t.Fatalf("unexpected database: %+v", result.Database)
+		}
+
+		if !reflect.DeepEqual(result.Features, []string{"auth", "metrics"}) {
+			t.Fatalf("unexpected features: %v", result.Features)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		result, err := ReadJSONOrYAMLFile[includeTestConfig](
+			context.Background(),
+			"testdata/include/app.json",
+			DownloadFileWithIncludes(),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if result.Database.Host != "${DB_HOST:-localhost}" || result.Database.Options["sslmode"] != "disable" {
+			t.Fatalf("unexpected database: %+v", result.Database)
+		}
+
+		if result.Schema[JSONRefKey] != "#/definitions/foo" {
+			t.Fatalf("expected the local reference to be kept, got: %v", result.Schema)
+		}
+	})
+
+	t.Run("disabled_by_default", func(t *testing.T) {
+		result, err := ReadJSONOrYAMLFile[map[string]any](context.Background(), "testdata/include/app.json")
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		database, ok := (*result)["database"].(map[string]any)
+		if !ok || database[JSONRefKey] != "nested/database.json" {
+			t.Fatalf("expected the reference to be kept, got: %v", (*result)["database"])
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		_, err := ReadJSONOrYAMLFile[map[string]any](
+			context.Background(),
+			"testdata/include/cycle-a.yaml",
+			DownloadFileWithIncludes(),
+		)
+
+		var cycleErr IncludeCycleError
+
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("expected IncludeCycleError, got: %v", err)
+		}
+
+		expected := []string{
+			"testdata/include/cycle-a.yaml",
+			"testdata/include/cycle-b.yaml",
+			"testdata/include/cycle-a.yaml",
+		}
+
+		if !reflect.DeepEqual(cycleErr.Chain, expected) {
+			t.Fatalf("expected chain %v, got: %v", expected, cycleErr.Chain)
+		}
+	})
+
+	t.Run("path_policy", func(t *testing.T) {
+		_, err := ReadJSONOrYAMLFile[map[string]any](
+			context.Background(),
+			"testdata/include/secret.yaml",
+			DownloadFileWithIncludes(),
+			DownloadFileIncludingPaths([]string{"testdata/include/*"}),
+		)
+		if !errors.Is(err, errDisallowedFilePath) {
+			t.Fatalf("expected errDisallowedFilePath, got: %v", err)
+		}
+	})
+
+	t.Run("file_source", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"config/app.yaml":         &fstest.MapFile{Data: []byte("database: !include db/database.yaml\n")},
+			"config/db/database.yaml": &fstest.MapFile{Data: []byte("host: db.internal\n")},
+		}
+
+		result, err := ReadJSONOrYAMLFile[includeTestConfig](
+			context.Background(),
+			"mem://config/app.yaml",
+			DownloadFileWithIncludes(),
+			DownloadFileWithSource("mem", NewFSFileSource(fsys)),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if result.Database.Host != "db.internal" {
+			t.Fatalf("unexpected database: %+v", result.Database)
+		}
+	})
+
+	t.Run("remote", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/configs/app.yaml":
+				_, _ = w.Write([]byte("name: remote\ndatabase: !include db.json\nfeatures: !include http://blocked.example.com/x.yaml\n"))
+			case "/configs/db.json":
+				_, _ = w.Write([]byte(`{"host": "remote-db"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		_, err := ReadJSONOrYAMLFile[includeTestConfig](
+			context.Background(),
+			server.URL+"/configs/app.yaml",
+			DownloadFileWithIncludes(),
+			DownloadFileWithBlockedHosts([]string{"blocked.example.com"}),
+		)
+		if !errors.Is(err, ErrInvalidURI) {
+			t.Fatalf("expected ErrInvalidURI, got: %v", err)
+		}
+	})
+
+	t.Run("remote_local_include", func(t *testing.T) {
+		absPath, err := filepath.Abs("testdata/include/nested/database.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		refs := []string{
+			"file://" + filepath.ToSlash(absPath),
+			"file:testdata/include/nested/database.json",
+			"mem://db.yaml",
+			`data:application/json,{"host":"data-db"}`,
+		}
+
+		for _, ref := range refs {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/app.yaml":
+					_, _ = w.Write([]byte("database: !include " + ref + "\n"))
+				case "/app.json":
+					_, _ = w.Write([]byte(`{"database": {"$ref": "` + strings.ReplaceAll(ref, `"`, `\"`) + `"}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			for _, name := range []string{"/app.yaml", "/app.json"} {
+				options := []DownloadFileOption{
+					DownloadFileWithIncludes(),
+					DownloadFileWithSource("mem", NewFSFileSource(fstest.MapFS{
+						"db.yaml": &fstest.MapFile{Data: []byte("host: mem-db\n")},
+					})),
+				}
+
+				_, err := ReadJSONOrYAMLFile[includeTestConfig](context.Background(), server.URL+name, options...)
+				if !errors.Is(err, errRemoteLocalInclude) {
+					t.Errorf("%s of %s: expected errRemoteLocalInclude, got: %v", ref, name, err)
+				}
+
+				result, err := ReadJSONOrYAMLFile[includeTestConfig](
+					context.Background(),
+					server.URL+name,
+					append(options, DownloadFileWithRemoteLocalIncludes())...,
+				)
+				if err != nil || result.Database.Host == "" {
+					t.Errorf("%s of %s: expected the include to be allowed, got: %v, %v", ref, name, result, err)
+				}
+			}
+
+			server.Close()
+		}
+	})
+}
+
+func TestResolveIncludePath(t *testing.T) {
+	testCases := []struct {
+		Parent   string
+		Ref      string
+		Expected string
+		Error    error
+	}{
+		{Parent: "configs/app.yaml", Ref: "db.yaml", Expected: "configs/db.yaml"},
+		{Parent: "configs/app.yaml", Ref: "../shared/db.yaml", Expected: "shared/db.yaml"},
+		{Parent: "configs/app.yaml", Ref: "/etc/db.yaml", Expected: "/etc/db.yaml"},
+		{Parent: "configs/app.yaml", Ref: "https://example.com/db.yaml", Expected: "https://example.com/db.yaml"},
+		{Parent: "https://example.com/configs/app.yaml", Ref: "db.yaml", Expected: "https://example.com/configs/db.yaml"},
+		{Parent: "https://example.com/configs/app.yaml", Ref: "/db.yaml", Expected: "https://example.com/db.yaml"},
+		{Parent: "file:///etc/configs/app.yaml", Ref: "db.yaml", Expected: "file:///etc/configs/db.yaml"},
+		{Parent: "embed:configs/app.yaml", Ref: "db.yaml", Expected: "embed:configs/db.yaml"},
+		{Parent: "data:,foo", Ref: "db.yaml", Error: errRelativeIncludePath},
+		{Parent: "configs/app.yaml", Ref: "db.yaml#/foo", Error: errIncludeFragment},
+		{Parent: "configs/app.yaml", Ref: " ", Error: errFilePathRequired},
+	}
+
+	for _, tc := range testCases {
+		result, err := resolveIncludePath(tc.Parent, tc.Ref)
+		if tc.Error != nil {
+			if !errors.Is(err, tc.Error) {
+				t.Errorf("%s + %s: expected error %v, got: %v", tc.Parent, tc.Ref, tc.Error, err)
+			}
+
+			continue
+		}
+
+		if err != nil || result != tc.Expected {
+			t.Errorf("%s + %s: expected %s, got: %s, %v", tc.Parent, tc.Ref, tc.Expected, result, err)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// ListMergeStrategy represents the strategy to merge lists of layered documents.
+type ListMergeStrategy string
+
+const (
+	// ListMergeReplace replaces the list of the lower layer with the list of the upper layer.
+	ListMergeReplace ListMergeStrategy = "replace"
+	// ListMergeAppend appends the items of the list of the upper layer to the list of the lower layer.
+	ListMergeAppend ListMergeStrategy = "append"
+)
+
+// MergedFile holds the decoded result of layered documents.
+type MergedFile[T any] struct {
+	// The decoded value of the merged document.
+	Value *T
+	// Sources maps the JSON Pointer of every leaf value to the path of the file it came from.
+	// Empty maps and lists are leaves too.
+	Sources map[string]string
+}
+
+// ReadMergedJSONOrYAMLFiles reads the first document of every path or URL in order
+// and deep-merges them into a value of T, so later files override earlier ones:
+//
+//   - Maps are merged key by key.
+//   - Lists are replaced by default, or appended with [ListMergeAppend].
+//   - An explicit null deletes the key from the result.
+//   - Other values are replaced.
+//
+// Every file is read with the same options as [ReadJSONOrYAMLFile]. The merged document
+// is decoded as JSON if every file is JSON, otherwise it's decoded as YAML.
+func ReadMergedJSONOrYAMLFiles[T any](
+	ctx context.Context,
+	filePaths []string,
+	options ...DownloadFileOption,
+) (*MergedFile[T], error) {
+	if len(filePaths) == 0 {
+		return nil, errFilePathRequired
+	}
+
+	opts := newDownloadFileOptions(options)
+	merger := documentMerger{
+		strategy: opts.ListMergeStrategy,
+		sources:  map[string]string{},
+	}
+
+	var merged any
+
+	isJSON := true
+
+	for _, filePath := range filePaths {
+		value, ext, err := readDocumentValue(ctx, filePath, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+
+		// Registered formats are decoded through JSON streams.
+		isJSON = isJSON && ext != ".yaml" && ext != ".yml"
+
+		if value != nil {
+			merged = merger.merge(merged, value, "", filePath)
+		}
+	}
+
+	err := opts.validateDocument(document{FilePath: strings.Join(filePaths, ", ")}, merged)
+	if err != nil {
+		return nil, err
+	}
+
+	var result T
+
+	err = decodeMergedValue(merged, isJSON, opts.Strict, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MergedFile[T]{
+		Value:   &result,
+		Sources: merger.sources,
+	}, nil
+}
+
+// readDocumentValue reads the first document of the file as a generic value.
+func readDocumentValue(
+	ctx context.Context,
+	filePath string,
+	options *downloadFileOptions,
+) (any, string, error) {
+	file, ext, err := fileReaderFromPath(ctx, filePath, options)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer CatchWarnErrorFunc(file.Close)
+
+	result, err := decodeDocumentValue(ctx, file, document{FilePath: filePath}, ext, options)
+
+	return result, ext, err
+}
+
+// decodeDocumentValue decodes the first document of the reader as a generic value.
+func decodeDocumentValue(
+	ctx context.Context,
+	reader io.Reader,
+	doc document,
+	ext string,
+	options *downloadFileOptions,
+) (any, error) {
+	var result any
+
+	var err error
+
+	switch ext {
+	case ".json":
+		err = walkJSONDocuments(ctx, reader, doc, options, 1, func(_ document, value any) error {
+			result = value
+
+			return nil
+		})
+	case ".yaml", ".yml":
+		err = walkYAMLDocuments(ctx, reader, doc, options, 1, func(doc document, node *yaml.Node) error {
+			decodeErr := node.Decode(&result)
+			if decodeErr != nil {
+				return newYAMLConfigDecodeError(doc, node, decodeErr)
+			}
+
+			return nil
+		})
+	default:
+		decoder := lookupFileDecoder(ext)
+		if decoder == nil {
+			return nil, errUnsupportedFilePathExtension
+		}
+
+		var jsonReader io.Reader
+
+		jsonReader, err = decoder.convertToJSON(reader, doc)
+		if err != nil {
+			return nil, err
+		}
+
+		return decodeDocumentValue(ctx, jsonReader, doc, ".json", options)
+	}
+
+	return result, err
+}
+
+func decodeMergedValue(value any, isJSON bool, strict bool, target any) error {
+	if isJSON {
+		rawBytes, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(rawBytes))
+
+		if strict {
+			decoder.DisallowUnknownFields()
+		}
+
+		return decoder.Decode(target)
+	}
+
+	var node yaml.Node
+
+	err := node.Encode(normalizeJSONNumbers(value))
+	if err != nil {
+		return err
+	}
+
+	return decodeYAMLNode(&node, target, strict)
+}
+
+// normalizeJSONNumbers converts json.Number values to numbers, so they are encoded as YAML numbers.
+func normalizeJSONNumbers(value any) any {
+	switch typedValue := value.(type) {
+	case json.Number:
+		if intValue, err := typedValue.Int64(); err == nil {
+			return intValue
+		}
+
+		if floatValue, err := typedValue.Float64(); err == nil {
+			return floatValue
+		}
+
+		return typedValue.String()
+	case map[string]any:
+		for key, item := range typedValue {
+			typedValue[key] = normalizeJSONNumbers(item)
+		}
+	case []any:
+		for i, item := range typedValue {
+			typedValue[i] = normalizeJSONNumbers(item)
+		}
+	default:
+	}
+
+	return value
+}
+
+type documentMerger struct {
+	strategy ListMergeStrategy
+	sources  map[string]string
+}
+
+// merge merges the source value into the destination value and returns the result.
+func (dm documentMerger) merge(dst any, src any, pointer string, source string) any {
+	switch srcValue := src.(type) {
+	case map[string]any:
+		dstMap, ok := dst.(map[string]any)
+		if !ok {
+			dm.removeSources(dst, pointer)
+
+			dstMap = map[string]any{}
+		}
+
+		for key, item := range srcValue {
+			itemPointer := appendJSONPointer(pointer, key)
+
+			if item == nil {
+				dm.removeSources(dstMap[key], itemPointer)
+				delete(dstMap, key)
+
+				continue
+			}
+
+			dstMap[key] = dm.merge(dstMap[key], item, itemPointer, source)
+		}
+
+		if len(dstMap) == 0 {
+			dm.sources[pointer] = source
+		} else {
+			delete(dm.sources, pointer)
+		}
+
+		return dstMap
+	case []any:
+		dstList, ok := dst.([]any)
+		if ok && dm.strategy == ListMergeAppend && len(srcValue) > 0 {
+			delete(dm.sources, pointer)
+
+			for i, item := range srcValue {
+				dm.recordSources(item, appendJSONPointer(pointer, strconv.Itoa(len(dstList)+i)), source)
+			}
+
+			return append(dstList, srcValue...)
+		}
+	default:
+	}
+
+	dm.removeSources(dst, pointer)
+	dm.recordSources(src, pointer, source)
+
+	return src
+}
+
+// recordSources records the source of every leaf of the value.
+func (dm documentMerger) recordSources(value any, pointer string, source string) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		if len(typedValue) == 0 {
+			break
+		}
+
+		for key, item := range typedValue {
+			dm.recordSources(item, appendJSONPointer(pointer, key), source)
+		}
+
+		return
+	case []any:
+		if len(typedValue) == 0 {
+			break
+		}
+
+		for i, item := range typedValue {
+			dm.recordSources(item, appendJSONPointer(pointer, strconv.Itoa(i)), source)
+		}
+
+		return
+	default:
+	}
+
+	dm.sources[pointer] = source
+}
+
+// removeSources removes the sources of the replaced value at the pointer. The sources are recorded
+// by the leaves of the value, so only the pointers of the value are visited instead of every source.
+func (dm documentMerger) removeSources(value any, pointer string) {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, item := range typedValue {
+			dm.removeSources(item, appendJSONPointer(pointer, key))
+		}
+	case []any:
+		for i, item := range typedValue {
+			dm.removeSources(item, appendJSONPointer(pointer, strconv.Itoa(i)))
+		}
+	default:
+	}
+
+	delete(dm.sources, pointer)
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type mergeTestConfig struct {
+	Name   string `json:"name" yaml:"name"`
+	Server struct {
+		Host string `json:"host" yaml:"host"`
+		Port int    `json:"port" yaml:"port"`
+		TLS  *struct {
+			Enabled bool   `json:"enabled" yaml:"enabled"`
+			Cert    string `json:"cert"    yaml:"cert"`
+		} `json:"tls" yaml:"tls"`
+	} `json:"server" yaml:"server"`
+	Features []string          `json:"features" yaml:"features"`
+	Labels   map[string]string `json:"labels"   yaml:"labels"`
+}
+
+func TestReadMergedJSONOrYAMLFiles(t *testing.T) {
+	filePaths := []string{
+		"testdata/merge/base.yaml",
+		"testdata/merge/production.json",
+	}
+
+	t.Run("replace_lists", func(t *testing.T) {
+		result, err := ReadMergedJSONOrYAMLFiles[mergeTestConfig](context.Background(), filePaths)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		value := result.Value
+
+		if value.Name != "app" || value.Server.Host != "0.0.0.0" || value.Server.Port != 443 {
+			t.Fatalf("unexpected merged value: %+v", value)
+		}
+
+		if value.Server.TLS == nil || !value.Server.TLS.Enabled || value.Server.TLS.Cert != "/etc/tls/cert.pem" {
+			t.Fatalf("unexpected tls: %+v", value.Server.TLS)
+		}
+
+		if !reflect.DeepEqual(value.Features, []string{"tracing"}) {
+			t.Fatalf("unexpected features: %v", value.Features)
+		}
+
+		if !reflect.DeepEqual(value.Labels, map[string]string{"team": "core"}) {
+			t.Fatalf("unexpected labels: %v", value.Labels)
+		}
+
+		expectedSources := map[string]string{
+			"/name":               "testdata/merge/base.yaml",
+			"/server/host":        "testdata/merge/base.yaml",
+			"/server/port":        "testdata/merge/production.json",
+			"/server/tls/enabled": "testdata/merge/production.json",
+			"/server/tls/cert":    "testdata/merge/production.json",
+			"/features/0":         "testdata/merge/production.json",
+			"/labels/team":        "testdata/merge/base.yaml",
+		}
+
+		if !reflect.DeepEqual(result.Sources, expectedSources) {
+			t.Fatalf("expected sources %v, got: %v", expectedSources, result.Sources)
+		}
+	})
+
+	t.Run("append_lists_and_delete", func(t *testing.T) {
+		result, err := ReadMergedJSONOrYAMLFiles[mergeTestConfig](
+			context.Background(),
+			append(filePaths, "testdata/merge/local.yaml"),
+			DownloadFileWithListMergeStrategy(ListMergeAppend),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		value := result.Value
+
+		if value.Server.TLS != nil {
+			t.Fatalf("expected deleted tls, got: %+v", value.Server.TLS)
+		}
+
+		if !reflect.DeepEqual(value.Features, []string{"auth", "metrics", "tracing"}) {
+			t.Fatalf("unexpected features: %v", value.Features)
+		}
+
+		if !reflect.DeepEqual(value.Labels, map[string]string{"team": "core", "owner": "me"}) {
+			t.Fatalf("unexpected labels: %v", value.Labels)
+		}
+
+		for pointer, expected := range map[string]string{
+			"/features/0":         "testdata/merge/base.yaml",
+			"/features/2":         "testdata/merge/production.json",
+			"/labels/owner":       "testdata/merge/local.yaml",
+			"/server/tls/enabled": "",
+		} {
+			if result.Sources[pointer] != expected {
+				t.Errorf("%s: expected source %q, got: %q", pointer, expected, result.Sources[pointer])
+			}
+		}
+	})
+
+	t.Run("json_only_keeps_numbers", func(t *testing.T) {
+		type limitsConfig struct {
+			Limits struct {
+				Max int64 `json:"max"`
+				Min int64 `json:"min"`
+			} `json:"limits"`
+		}
+
+		result, err := ReadMergedJSONOrYAMLFiles[limitsConfig](
+			context.Background(),
+			[]string{"testdata/merge/base.json", "testdata/merge/override.json"},
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if result.Value.Limits.Max != 9007199254740993 || result.Value.Limits.Min != 1 {
+			t.Fatalf("unexpected limits: %+v", result.Value.Limits)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ReadMergedJSONOrYAMLFiles[mergeTestConfig](context.Background(), nil)
+		if !errors.Is(err, errFilePathRequired) {
+			t.Fatalf("expected errFilePathRequired, got: %v", err)
+		}
+
+		_, err = ReadMergedJSONOrYAMLFiles[mergeTestConfig](
+			context.Background(),
+			[]string{"testdata/merge/base.yaml", "testdata/merge/not-found.yaml"},
+		)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestDocumentMerger_Sources(t *testing.T) {
+	merger := documentMerger{strategy: ListMergeReplace, sources: map[string]string{}}
+
+	var merged any
+
+	for _, layer := range []struct {
+		Source string
+		Value  any
+	}{
+		{Source: "base", Value: map[string]any{
+			"a":  map[string]any{"b": 1, "c": []any{1, 2}},
+			"ab": "x",
+			"d":  []any{map[string]any{"e": 1}},
+			"f":  map[string]any{},
+		}},
+		{Source: "override", Value: map[string]any{
+			"a": "replaced",
+			"d": map[string]any{"e": 2},
+			"f": nil,
+		}},
+	} {
+		merged = merger.merge(merged, layer.Value, "", layer.Source)
+	}
+
+	assertDeepEqual[any](t, map[string]any{"a": "replaced", "ab": "x", "d": map[string]any{"e": 2}}, merged)
+	assertDeepEqual(t, map[string]string{"/a": "override", "/ab": "base", "/d/e": "override"}, merger.sources)
+}
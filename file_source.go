@@ -0,0 +1,280 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	fileURLScheme = "file"
+	dataURLScheme = "data"
+)
+
+var (
+	errMalformedDataURL = errors.New("malformed data URL")
+	errInvalidFileHost  = errors.New("file URL must not have a host other than localhost")
+)
+
+var (
+	fileSourcesLock sync.RWMutex
+	fileSources     = map[string]FileSource{
+		fileURLScheme: localFileSource{},
+		dataURLScheme: FileSourceFunc(openDataURL),
+	}
+)
+
+// FileSource abstracts a handler to open files of a URL scheme.
+type FileSource interface {
+	// Open opens the file of the URL. It returns the reader and the content type of the file if known.
+	Open(ctx context.Context, fileURL *url.URL) (io.ReadCloser, string, error)
+}
+
+// FileSourceFunc is a function adapter that implements the [FileSource] interface.
+type FileSourceFunc func(ctx context.Context, fileURL *url.URL) (io.ReadCloser, string, error)
+
+// Open implements the [FileSource] interface.
+func (fn FileSourceFunc) Open(ctx context.Context, fileURL *url.URL) (io.ReadCloser, string, error) {
+	return fn(ctx, fileURL)
+}
+
+// RegisterFileSource registers the file source of a URL scheme globally, replacing the existing one.
+// The http and https schemes are always handled by the HTTP client, so they can't be registered.
+// It panics if the scheme is invalid or the source is nil.
+func RegisterFileSource(scheme string, source FileSource) {
+	if source == nil {
+		panic("goutils: RegisterFileSource source is nil")
+	}
+
+	if !isFileSourceScheme(scheme) {
+		panic("goutils: RegisterFileSource invalid scheme " + scheme)
+	}
+
+	fileSourcesLock.Lock()
+	defer fileSourcesLock.Unlock()
+
+	fileSources[strings.ToLower(scheme)] = source
+}
+
+// NewFSFileSource creates a file source that opens files from a file system such as [embed.FS].
+// The host and path of the URL are joined as the file name,
+// e.g. "embed://config/app.yaml", "embed:///config/app.yaml" and "embed:config/app.yaml"
+// open the same "config/app.yaml" file.
+func NewFSFileSource(fsys fs.FS) FileSource {
+	return FileSourceFunc(func(_ context.Context, fileURL *url.URL) (io.ReadCloser, string, error) {
+		name := strings.TrimPrefix(fileSourcePath(fileURL), "/")
+
+		reader, err := fsys.Open(name)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return reader, "", nil
+	})
+}
+
+// lookupFileSource returns the parsed URL and the file source if the file path
+// starts with the scheme of a registered file source.
+func lookupFileSource(
+	filePath string,
+	options *downloadFileOptions,
+) (*url.URL, FileSource, error) {
+	scheme, _, found := strings.Cut(filePath, ":")
+	if !found || !isFileSourceScheme(scheme) {
+		return nil, nil, nil
+	}
+
+	scheme = strings.ToLower(scheme)
+	source := options.FileSources[scheme]
+
+	if source == nil {
+		fileSourcesLock.RLock()
+		source = fileSources[scheme]
+		fileSourcesLock.RUnlock()
+	}
+
+	if source == nil {
+		return nil, nil, nil
+	}
+
+	fileURL, err := url.Parse(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fileURL, source, nil
+}
+
+func openFileSource(
+	ctx context.Context,
+	fileURL *url.URL,
+	source FileSource,
+	options *downloadFileOptions,
+) (io.ReadCloser, string, string, error) {
+	// Local file URLs are validated and opened as plain paths,
+	// so the include and exclude rules apply to the exact path of the opened file.
+	if _, ok := source.(localFileSource); ok {
+		filePath, err := localFileURLPath(fileURL)
+		if err != nil {
+			return nil, "", "", err
+		}
+
+		return openLocalFile(filePath, options)
+	}
+
+	var ext string
+
+	// Data URLs don't have a path.
+	filePath := ""
+
+	if fileURL.Scheme != dataURLScheme {
+		filePath = fileSourcePath(fileURL)
+		ext = strings.ToLower(path.Ext(filePath))
+	}
+
+	err := validateFilePath(filePath, path.Match, options)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	cancel := context.CancelFunc(func() {})
+
+	if options.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+	}
+
+	reader, contentType, err := source.Open(ctx, fileURL)
+	if err != nil {
+		cancel()
+
+		return nil, "", "", err
+	}
+
+	return &cancelReadCloser{ReadCloser: reader, cancel: cancel}, ext, contentType, nil
+}
+
+// fileSourcePath returns the cleaned absolute path of a file source URL.
+func fileSourcePath(fileURL *url.URL) string {
+	filePath := fileURL.Opaque
+	if filePath == "" {
+		filePath = fileURL.Host + "/" + fileURL.Path
+	}
+
+	return path.Clean("/" + filePath)
+}
+
+// localFileSource opens local file URLs, e.g. file:///etc/config.json, file://localhost/etc/config.json
+// and file:testdata/config.json for relative paths.
+type localFileSource struct{}
+
+// Open implements the [FileSource] interface.
+func (localFileSource) Open(_ context.Context, fileURL *url.URL) (io.ReadCloser, string, error) {
+	filePath, err := localFileURLPath(fileURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	reader, err := os.Open(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return reader, "", nil
+}
+
+// localFileURLPath returns the cleaned OS path of a local file URL.
+func localFileURLPath(fileURL *url.URL) (string, error) {
+	if fileURL.Host != "" && !strings.EqualFold(fileURL.Host, "localhost") {
+		return "", fmt.Errorf("%w: %s", errInvalidFileHost, fileURL.Host)
+	}
+
+	filePath := fileURL.Path
+	if fileURL.Opaque != "" {
+		// A relative path, e.g. file:testdata/config.json.
+		filePath = fileURL.Opaque
+	}
+
+	return filepath.Clean(filepath.FromSlash(filePath)), nil
+}
+
+// openDataURL decodes the content of an RFC 2397 data URL:
+//
+//	data:[<mediatype>][;base64],<data>
+func openDataURL(_ context.Context, fileURL *url.URL) (io.ReadCloser, string, error) {
+	mediaType, rawData, found := strings.Cut(fileURL.Opaque, ",")
+	if !found {
+		return nil, "", fmt.Errorf("%w: missing comma", errMalformedDataURL)
+	}
+
+	isBase64 := HasStringSuffixFold(mediaType, ";base64")
+	if isBase64 {
+		mediaType = mediaType[:len(mediaType)-len(";base64")]
+	}
+
+	if mediaType == "" || mediaType[0] == ';' {
+		mediaType = "text/plain" + mediaType
+	}
+
+	data, err := url.PathUnescape(rawData)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", errMalformedDataURL, err)
+	}
+
+	if !isBase64 {
+		return io.NopCloser(strings.NewReader(data)), mediaType, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		decoded, err = base64.RawStdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %w", errMalformedDataURL, err)
+		}
+	}
+
+	return io.NopCloser(bytes.NewReader(decoded)), mediaType, nil
+}
+
+// isFileSourceScheme checks if the input is a valid URL scheme for file sources.
+// Single-letter schemes are rejected because they are Windows drive letters.
+func isFileSourceScheme(scheme string) bool {
+	if len(scheme) < 2 || !IsLowerAlphabet(scheme[0]) && !IsUpperAlphabet(scheme[0]) {
+		return false
+	}
+
+	for _, c := range []byte(scheme[1:]) {
+		if !IsLowerAlphabet(c) && !IsUpperAlphabet(c) && !IsDigit(c) &&
+			c != '+' && c != '-' && c != '.' {
+			return false
+		}
+	}
+
+	return !slices.ContainsFunc(httpSchemes, func(item string) bool {
+		return strings.EqualFold(item, scheme)
+	})
+}
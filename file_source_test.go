@@ -0,0 +1,292 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFileSource_File(t *testing.T) {
+	absPath, err := filepath.Abs("testdata/config.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileURL := "file://" + filepath.ToSlash(absPath)
+
+	t.Run("read_file_url", func(t *testing.T) {
+		result, err := ReadJSONOrYAMLFile[map[string]string](context.Background(), fileURL)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		expected := map[string]string{"foo": "baz"}
+		if !reflect.DeepEqual(*result, expected) {
+			t.Fatalf("expected %v, got: %v", expected, *result)
+		}
+	})
+
+	t.Run("exclude_path", func(t *testing.T) {
+		_, err := ReadJSONOrYAMLFile[map[string]string](
+			context.Background(),
+			fileURL,
+			DownloadFileExcludingPaths([]string{filepath.ToSlash(absPath)}),
+		)
+		if !errors.Is(err, errDisallowedFilePath) {
+			t.Fatalf("expected errDisallowedFilePath, got: %v", err)
+		}
+	})
+
+	t.Run("exclude_path_bypass", func(t *testing.T) {
+		absDir := filepath.ToSlash(filepath.Dir(absPath))
+
+		testCases := []struct {
+			Name    string
+			URL     string
+			Exclude string
+		}{
+			{Name: "relative", URL: "file:testdata/config.json", Exclude: "testdata/*.json"},
+			{Name: "localhost", URL: "file://localhost" + filepath.ToSlash(absPath), Exclude: absDir + "/*.json"},
+			{Name: "dot_dot", URL: "file:testdata/../testdata/config.json", Exclude: "testdata/*.json"},
+			{Name: "absolute_dot_dot", URL: "file://" + absDir + "/../testdata/config.json", Exclude: absDir + "/*.json"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.Name, func(t *testing.T) {
+				_, err := ReadJSONOrYAMLFile[map[string]string](
+					context.Background(),
+					tc.URL,
+					DownloadFileExcludingPaths([]string{tc.Exclude}),
+				)
+				if !errors.Is(err, errDisallowedFilePath) {
+					t.Fatalf("expected errDisallowedFilePath, got: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("include_path", func(t *testing.T) {
+		_, err := ReadJSONOrYAMLFile[map[string]string](
+			context.Background(),
+			"file:testdata/config.json",
+			DownloadFileIncludingPaths([]string{"testdata/*.json"}),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		_, err = ReadJSONOrYAMLFile[map[string]string](
+			context.Background(),
+			"file:testdata/../go.mod",
+			DownloadFileIncludingPaths([]string{"testdata/*"}),
+		)
+		if !errors.Is(err, errDisallowedFilePath) {
+			t.Fatalf("expected errDisallowedFilePath, got: %v", err)
+		}
+	})
+
+	t.Run("remote_host", func(t *testing.T) {
+		_, _, err := FileReaderFromPath(context.Background(), "file://example.com/etc/config.json")
+		if !errors.Is(err, errInvalidFileHost) {
+			t.Fatalf("expected errInvalidFileHost, got: %v", err)
+		}
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		_, _, err := FileReaderFromPath(context.Background(), "file:///not-found/config.json")
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected os.ErrNotExist, got: %v", err)
+		}
+	})
+}
+
+func TestFileSource_Data(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		URL      string
+		Expected map[string]string
+	}{
+		{
+			Name:     "json",
+			URL:      `data:application/json,{"foo":"bar"}`,
+			Expected: map[string]string{"foo": "bar"},
+		},
+		{
+			Name:     "percent_encoded_yaml",
+			URL:      "data:application/yaml,foo:%20bar%0Abaz:%20qux",
+			Expected: map[string]string{"foo": "bar", "baz": "qux"},
+		},
+		{
+			Name:     "base64",
+			URL:      "data:application/json;base64,eyJmb28iOiJiYXIifQ==",
+			Expected: map[string]string{"foo": "bar"},
+		},
+		{
+			Name:     "unpadded_base64",
+			URL:      "data:;base64,eyJmb28iOiJiYXIifQ",
+			Expected: map[string]string{"foo": "bar"},
+		},
+		{
+			Name:     "sniff_yaml",
+			URL:      "data:,foo:%20bar",
+			Expected: map[string]string{"foo": "bar"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := ReadJSONOrYAMLFile[map[string]string](context.Background(), tc.URL)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			if !reflect.DeepEqual(*result, tc.Expected) {
+				t.Fatalf("expected %v, got: %v", tc.Expected, *result)
+			}
+		})
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, dataURL := range []string{
+			"data:application/json",
+			"data:application/json;base64,!!!",
+			"data:,%zz",
+		} {
+			_, _, err := FileReaderFromPath(context.Background(), dataURL)
+			if !errors.Is(err, errMalformedDataURL) {
+				t.Errorf("%s: expected errMalformedDataURL, got: %v", dataURL, err)
+			}
+		}
+	})
+
+	t.Run("include_paths_reject_data_url", func(t *testing.T) {
+		_, _, err := FileReaderFromPath(
+			context.Background(),
+			`data:application/json,{}`,
+			DownloadFileIncludingPaths([]string{"/configs/*"}),
+		)
+		if !errors.Is(err, errDisallowedFilePath) {
+			t.Fatalf("expected errDisallowedFilePath, got: %v", err)
+		}
+	})
+}
+
+func TestFileSource_FS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config/app.yaml":   &fstest.MapFile{Data: []byte("foo: bar\n")},
+		"config/app.json":   &fstest.MapFile{Data: []byte(`{"foo": "baz"}`)},
+		"secrets/app.yaml":  &fstest.MapFile{Data: []byte("password: secret\n")},
+		"config/no-ext-doc": &fstest.MapFile{Data: []byte(`{"foo": "qux"}`)},
+	}
+
+	options := []DownloadFileOption{
+		DownloadFileWithSource("mem", NewFSFileSource(fsys)),
+		DownloadFileExcludingPaths([]string{"/secrets/*"}),
+	}
+
+	for filePath, expected := range map[string]string{
+		"mem://config/app.yaml":   "bar",
+		"mem:///config/app.yaml":  "bar",
+		"mem:config/app.json":     "baz",
+		"MEM://config/no-ext-doc": "qux",
+	} {
+		t.Run(filePath, func(t *testing.T) {
+			result, err := ReadJSONOrYAMLFile[map[string]string](context.Background(), filePath, options...)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			if (*result)["foo"] != expected {
+				t.Fatalf("expected %s, got: %v", expected, *result)
+			}
+		})
+	}
+
+	t.Run("excluded_path", func(t *testing.T) {
+		_, _, err := FileReaderFromPath(context.Background(), "mem://secrets/app.yaml", options...)
+		if !errors.Is(err, errDisallowedFilePath) {
+			t.Fatalf("expected errDisallowedFilePath, got: %v", err)
+		}
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		_, _, err := FileReaderFromPath(context.Background(), "mem://config/missing.yaml", options...)
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("expected fs.ErrNotExist, got: %v", err)
+		}
+	})
+}
+
+func TestRegisterFileSource(t *testing.T) {
+	var openedURL string
+
+	RegisterFileSource("test-store", FileSourceFunc(
+		func(_ context.Context, fileURL *url.URL) (io.ReadCloser, string, error) {
+			openedURL = fileURL.String()
+
+			return io.NopCloser(strings.NewReader("foo: bar")), "application/yaml", nil
+		},
+	))
+
+	t.Cleanup(func() {
+		fileSourcesLock.Lock()
+		delete(fileSources, "test-store")
+		fileSourcesLock.Unlock()
+	})
+
+	result, err := ReadJSONOrYAMLFile[map[string]string](context.Background(), "test-store://team/app")
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	if (*result)["foo"] != "bar" {
+		t.Fatalf("expected bar, got: %v", *result)
+	}
+
+	if openedURL != "test-store://team/app" {
+		t.Fatalf("unexpected opened URL: %s", openedURL)
+	}
+
+	t.Run("invalid_scheme", func(t *testing.T) {
+		for _, scheme := range []string{"", "c", "http", "HTTPS", "1abc", "a_b"} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%q: expected panic", scheme)
+					}
+				}()
+
+				RegisterFileSource(scheme, NewFSFileSource(fstest.MapFS{}))
+			}()
+		}
+	})
+
+	t.Run("windows_drive_letter_is_not_a_scheme", func(t *testing.T) {
+		_, source, err := lookupFileSource(`C:\config.json`, newDownloadFileOptions(nil))
+		if err != nil || source != nil {
+			t.Fatalf("expected no file source, got: %v, %v", source, err)
+		}
+	})
+}
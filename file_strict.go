@@ -0,0 +1,201 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// yamlMergeKey is the key of YAML mappings that merges other mappings.
+const yamlMergeKey = "<<"
+
+type jsonDuplicateFrame struct {
+	object    bool
+	expectKey bool
+	pointer   string
+	key       string
+	index     int
+	keys      map[string]struct{}
+}
+
+// childPointer returns the JSON Pointer of the current value of the object or array.
+func (f *jsonDuplicateFrame) childPointer() string {
+	if f.object {
+		return appendJSONPointer(f.pointer, f.key)
+	}
+
+	return appendJSONPointer(f.pointer, strconv.Itoa(f.index))
+}
+
+// next moves the frame to the next key or item after a value is consumed.
+func (f *jsonDuplicateFrame) next() {
+	if f.object {
+		f.expectKey = true
+	} else {
+		f.index++
+	}
+}
+
+// checkJSONDuplicateKeys scans the JSON document between the start and end stream offsets of the content
+// and reports every duplicate key of objects. The document must be syntactically valid.
+func checkJSONDuplicateKeys(doc document, content *jsonStreamContent, start int64, end int64) error {
+	decoder := json.NewDecoder(bytes.NewReader(content.bytes(start, end)))
+
+	var stack []*jsonDuplicateFrame
+
+	var results ConfigDecodeErrors
+
+	for {
+		offset := start + decoder.InputOffset()
+
+		token, err := decoder.Token()
+		if err != nil {
+			// The end of the document. Syntax errors are already reported by the decoder.
+			break
+		}
+
+		var top *jsonDuplicateFrame
+
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		if top != nil && top.object && top.expectKey {
+			key, isKey := token.(string)
+			if !isKey {
+				// The end of the object.
+				stack = stack[:len(stack)-1]
+				nextJSONDuplicateFrame(stack)
+
+				continue
+			}
+
+			top.key = key
+			top.expectKey = false
+
+			if _, exists := top.keys[key]; !exists {
+				top.keys[key] = struct{}{}
+
+				continue
+			}
+
+			// Locate the opening quote of the key after the separators of the previous value.
+			line, column := content.location(content.skip(offset, " \t\r\n,") + 1)
+
+			results = append(results, ConfigDecodeError{
+				FilePath:      doc.FilePath,
+				DocumentIndex: doc.Index,
+				Line:          line,
+				Column:        column,
+				Pointer:       top.childPointer(),
+				Err:           fmt.Errorf("%w %q", ErrDuplicateKey, key),
+			})
+
+			continue
+		}
+
+		pointer := ""
+
+		if top != nil {
+			pointer = top.childPointer()
+		}
+
+		switch token {
+		case json.Delim('{'):
+			stack = append(stack, &jsonDuplicateFrame{
+				object:    true,
+				expectKey: true,
+				pointer:   pointer,
+				keys:      map[string]struct{}{},
+			})
+		case json.Delim('['):
+			stack = append(stack, &jsonDuplicateFrame{pointer: pointer})
+		case json.Delim(']'):
+			stack = stack[:len(stack)-1]
+			nextJSONDuplicateFrame(stack)
+		default:
+			nextJSONDuplicateFrame(stack)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	return results
+}
+
+func nextJSONDuplicateFrame(stack []*jsonDuplicateFrame) {
+	if len(stack) > 0 {
+		stack[len(stack)-1].next()
+	}
+}
+
+// checkYAMLDuplicateKeys reports every duplicate scalar key of mappings in the YAML node.
+func checkYAMLDuplicateKeys(doc document, node *yaml.Node) error {
+	var results ConfigDecodeErrors
+
+	collectYAMLDuplicateKeys(doc, node, "", &results)
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	return results
+}
+
+func collectYAMLDuplicateKeys(doc document, node *yaml.Node, pointer string, results *ConfigDecodeErrors) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, item := range node.Content {
+			collectYAMLDuplicateKeys(doc, item, pointer, results)
+		}
+	case yaml.MappingNode:
+		keys := map[string]struct{}{}
+
+		for i := 1; i < len(node.Content); i += 2 {
+			keyNode := node.Content[i-1]
+			itemPointer := appendJSONPointer(pointer, keyNode.Value)
+
+			if keyNode.Kind == yaml.ScalarNode && keyNode.Value != yamlMergeKey {
+				if _, exists := keys[keyNode.Value]; exists {
+					*results = append(*results, ConfigDecodeError{
+						FilePath:      doc.FilePath,
+						DocumentIndex: doc.Index,
+						Line:          keyNode.Line,
+						Column:        keyNode.Column,
+						Pointer:       itemPointer,
+						Err:           fmt.Errorf("%w %q", ErrDuplicateKey, keyNode.Value),
+					})
+				}
+
+				keys[keyNode.Value] = struct{}{}
+			}
+
+			collectYAMLDuplicateKeys(doc, node.Content[i], itemPointer, results)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			collectYAMLDuplicateKeys(doc, item, appendJSONPointer(pointer, strconv.Itoa(i)), results)
+		}
+	default:
+		// Aliases point to anchored nodes that are already checked.
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type strictTestConfig struct {
+	Name   string `json:"name" yaml:"name"`
+	Server struct {
+		Port int `json:"port" yaml:"port"`
+	} `json:"server" yaml:"server"`
+}
+
+func TestDownloadFileWithStrict_Duplicates(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		URL      string
+		Multi    bool
+		Options  []DownloadFileOption
+		Expected []ConfigDecodeError
+	}{
+		{
+			Name: "json",
+			URL: "data:application/json," + url.PathEscape(
+				"{\n  \"name\": \"a\",\n  \"server\": {\"port\": 1, \"port\": 2},\n  \"name\": \"b\"\n}",
+			),
+			Expected: []ConfigDecodeError{
+				{Line: 3, Column: 25, Pointer: "/server/port"},
+				{Line: 4, Column: 3, Pointer: "/name"},
+			},
+		},
+		{
+			Name:  "json_multi_documents",
+			URL:   "data:application/json," + url.PathEscape("{\"name\": \"a\"}\n[{\"name\": \"b\", \"name\": \"c\"}]"),
+			Multi: true,
+			Expected: []ConfigDecodeError{
+				{DocumentIndex: 1, Line: 2, Column: 16, Pointer: "/0/name"},
+			},
+		},
+		{
+			Name:    "json_transformed",
+			URL:     "data:application/json," + url.PathEscape("{\"name\": \"${NAME}\",\n\"name\": \"b\"}"),
+			Options: []DownloadFileOption{DownloadFileWithEnvExpansion()},
+			Expected: []ConfigDecodeError{
+				{Line: 2, Column: 1, Pointer: "/name"},
+			},
+		},
+		{
+			Name: "yaml",
+			URL: "data:application/yaml," + url.PathEscape(
+				"name: a\nserver:\n  port: 1\n  port: 2\nname: b\nbase: &base\n  port: 1\nother:\n  <<: *base\n  <<: *base\n",
+			),
+			Expected: []ConfigDecodeError{
+				{Line: 4, Column: 3, Pointer: "/server/port"},
+				{Line: 5, Column: 1, Pointer: "/name"},
+			},
+		},
+		{
+			Name:  "yaml_multi_documents",
+			URL:   "data:application/yaml," + url.PathEscape("name: a\n---\n- name: b\n  name: c\n"),
+			Multi: true,
+			Expected: []ConfigDecodeError{
+				{DocumentIndex: 1, Line: 4, Column: 3, Pointer: "/0/name"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			options := append([]DownloadFileOption{DownloadFileWithStrict()}, tc.Options...)
+
+			var err error
+
+			if tc.Multi {
+				_, err = ReadMultiFromJSONOrYAMLFile[any](context.Background(), tc.URL, options...)
+			} else {
+				_, err = ReadJSONOrYAMLFile[any](context.Background(), tc.URL, options...)
+			}
+
+			var decodeErrs ConfigDecodeErrors
+
+			if !errors.As(err, &decodeErrs) {
+				t.Fatalf("expected ConfigDecodeErrors, got: %v", err)
+			}
+
+			if !errors.Is(err, ErrDuplicateKey) {
+				t.Fatalf("expected ErrDuplicateKey, got: %v", err)
+			}
+
+			if len(decodeErrs) != len(tc.Expected) {
+				t.Fatalf("expected %d errors, got: %s", len(tc.Expected), err)
+			}
+
+			for i, expected := range tc.Expected {
+				decodeErr := decodeErrs[i]
+
+				if decodeErr.DocumentIndex != expected.DocumentIndex || decodeErr.Line != expected.Line ||
+					decodeErr.Column != expected.Column || decodeErr.Pointer != expected.Pointer {
+					t.Errorf(
+						"%d: expected (%d, %d:%d, %s), got: (%d, %d:%d, %s)",
+						i, expected.DocumentIndex, expected.Line, expected.Column, expected.Pointer,
+						decodeErr.DocumentIndex, decodeErr.Line, decodeErr.Column, decodeErr.Pointer,
+					)
+				}
+			}
+
+			if len(decodeErrs.ValidationErrors()) != len(tc.Expected) {
+				t.Fatalf("expected %d validation errors", len(tc.Expected))
+			}
+		})
+	}
+}
+
+func TestDownloadFileWithStrict_UnknownFields(t *testing.T) {
+	testCases := []struct {
+		Name string
+		URL  string
+	}{
+		{
+			Name: "json",
+			URL:  "data:application/json," + url.PathEscape(`{"name": "a", "server": {"port": 1, "host": "x"}}`),
+		},
+		{
+			Name: "yaml",
+			URL:  "data:application/yaml," + url.PathEscape("name: a\nserver:\n  port: 1\n  host: x\n"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ReadJSONOrYAMLFile[strictTestConfig](context.Background(), tc.URL)
+			if err != nil {
+				t.Fatalf("expected nil error without the strict mode, got: %s", err)
+			}
+
+			_, err = ReadJSONOrYAMLFile[strictTestConfig](context.Background(), tc.URL, DownloadFileWithStrict())
+			if err == nil || !strings.Contains(err.Error(), "host") {
+				t.Fatalf("expected unknown field error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadMultiDocumentStream_Strict(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		input := "{\"name\": \"a\"}\n{\"name\": \"b\", \"name\": \"c\"}"
+
+		results, err := LoadMultiJSONDocumentStream[strictTestConfig](strings.NewReader(input))
+		if err != nil || len(results) != 2 || results[1].Name != "c" {
+			t.Fatalf("expected the last value to win without the strict mode, got: %v, %v", results, err)
+		}
+
+		_, err = LoadMultiJSONDocumentStream[strictTestConfig](strings.NewReader(input), DownloadFileWithStrict())
+		if !errors.Is(err, ErrDuplicateKey) {
+			t.Fatalf("expected ErrDuplicateKey, got: %v", err)
+		}
+
+		if err.Error() != `failed to decode document 1 at line 2, column 15 (/name): duplicate key "name"` {
+			t.Fatalf("unexpected error message: %s", err)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		input := "name: a\n---\nname: b\nport: 1\n"
+
+		results, err := LoadMultiYAMLDocumentStream[strictTestConfig](strings.NewReader(input))
+		if err != nil || len(results) != 2 {
+			t.Fatalf("expected nil error without the strict mode, got: %v, %v", results, err)
+		}
+
+		_, err = LoadMultiYAMLDocumentStream[strictTestConfig](strings.NewReader(input), DownloadFileWithStrict())
+
+		var decodeErr ConfigDecodeError
+
+		if !errors.As(err, &decodeErr) || decodeErr.DocumentIndex != 1 || decodeErr.Line != 4 {
+			t.Fatalf("expected unknown field error at document 1, line 4, got: %v", err)
+		}
+	})
+}
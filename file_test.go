@@ -17,11 +17,14 @@ package goutils
 import (
 	"context"
 	"errors"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/relychan/goutils/httperror"
 )
@@ -389,7 +392,7 @@ features:
 		}
 	})
 
-	t.Run("url_with_query_parameters_limitation", func(t *testing.T) {
+	t.Run("url_with_query_parameters", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
@@ -397,12 +400,14 @@ features:
 		}))
 		defer server.Close()
 
-		// Note: This is a known limitation - filepath.Ext() includes query parameters
-		// in the extension, so "config.json?version=v1" has extension ".json?version=v1"
-		// which doesn't match ".json"
-		_, err := ReadJSONOrYAMLFile[map[string]string](context.Background(), server.URL+"/config.json?version=v1")
-		if !errors.Is(err, errUnsupportedFilePathExtension) {
-			t.Fatalf("expected unsupported extension error due to query params, got: %s", err)
+		// The extension is detected from the URL path, so query parameters are ignored.
+		result, err := ReadJSONOrYAMLFile[map[string]string](context.Background(), server.URL+"/config.json?version=v1")
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if (*result)["status"] != "ok" {
+			t.Errorf("expected status 'ok', got: %v", *result)
 		}
 	})
 
@@ -1062,3 +1067,394 @@ func TestFileReaderFromPath_AllowedBlockedHosts(t *testing.T) {
 		defer reader.Close()
 	})
 }
+
+func TestFileReaderFromPath_Redirects(t *testing.T) {
+	newRedirectServer := func(t *testing.T, location string) *httptest.Server {
+		t.Helper()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/data/config.json", "/private/config.json":
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"ok": true}`))
+			case "/loop":
+				http.Redirect(w, r, "/loop", http.StatusFound)
+			default:
+				http.Redirect(w, r, location, http.StatusFound)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	t.Run("follows_allowed_redirect", func(t *testing.T) {
+		server := newRedirectServer(t, "/data/config.json")
+
+		result, err := ReadJSONOrYAMLFile[map[string]bool](
+			context.Background(),
+			server.URL+"/config.json",
+			DownloadFileWithAllowedHosts([]string{"127.0.0.1"}),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if !(*result)["ok"] {
+			t.Fatalf("expected ok to be true, got: %v", *result)
+		}
+	})
+
+	t.Run("rejects_redirect_to_blocked_host", func(t *testing.T) {
+		server := newRedirectServer(t, "http://localhost/internal.json")
+
+		_, _, err := FileReaderFromPath(
+			context.Background(),
+			server.URL+"/config.json",
+			DownloadFileWithBlockedHosts([]string{"localhost"}),
+		)
+
+		var redirectErr RedirectError
+		if !errors.As(err, &redirectErr) {
+			t.Fatalf("expected RedirectError, got: %v", err)
+		}
+
+		if redirectErr.Hop != 1 || redirectErr.Location != "http://localhost/internal.json" {
+			t.Fatalf("unexpected redirect error: %+v", redirectErr)
+		}
+
+		if !errors.Is(err, ErrInvalidURI) {
+			t.Fatalf("expected ErrInvalidURI, got: %v", err)
+		}
+	})
+
+	t.Run("rejects_redirect_to_excluded_path", func(t *testing.T) {
+		server := newRedirectServer(t, "/private/config.json")
+
+		_, _, err := FileReaderFromPath(
+			context.Background(),
+			server.URL+"/config.json",
+			DownloadFileExcludingPaths([]string{"/private/*"}),
+		)
+		if !errors.Is(err, errDisallowedFilePath) {
+			t.Fatalf("expected errDisallowedFilePath, got: %v", err)
+		}
+	})
+
+	t.Run("rejects_redirect_to_disallowed_scheme", func(t *testing.T) {
+		server := newRedirectServer(t, "file:///etc/passwd")
+
+		_, _, err := FileReaderFromPath(context.Background(), server.URL+"/config.json")
+		if !errors.Is(err, ErrInvalidURLScheme) {
+			t.Fatalf("expected ErrInvalidURLScheme, got: %v", err)
+		}
+	})
+
+	t.Run("rejects_http_when_only_https_allowed", func(t *testing.T) {
+		server := newRedirectServer(t, "/data/config.json")
+
+		_, _, err := FileReaderFromPath(
+			context.Background(),
+			server.URL+"/config.json",
+			DownloadFileWithAllowedSchemes([]string{"https"}),
+		)
+		if !errors.Is(err, ErrInvalidURLScheme) {
+			t.Fatalf("expected ErrInvalidURLScheme, got: %v", err)
+		}
+	})
+
+	t.Run("too_many_redirects", func(t *testing.T) {
+		server := newRedirectServer(t, "/loop")
+
+		_, _, err := FileReaderFromPath(
+			context.Background(),
+			server.URL+"/config.json",
+			DownloadFileWithMaxRedirects(3),
+		)
+
+		var redirectErr RedirectError
+		if !errors.As(err, &redirectErr) || !errors.Is(err, errTooManyRedirects) {
+			t.Fatalf("expected too many redirects error, got: %v", err)
+		}
+
+		if redirectErr.Hop != 4 {
+			t.Fatalf("expected the 4th hop to be rejected, got: %d", redirectErr.Hop)
+		}
+	})
+
+	t.Run("redirects_disabled", func(t *testing.T) {
+		server := newRedirectServer(t, "/data/config.json")
+
+		_, _, err := FileReaderFromPath(
+			context.Background(),
+			server.URL+"/config.json",
+			DownloadFileWithMaxRedirects(0),
+		)
+		if !errors.Is(err, errTooManyRedirects) {
+			t.Fatalf("expected errTooManyRedirects, got: %v", err)
+		}
+	})
+
+	t.Run("validates_final_url_of_custom_doer", func(t *testing.T) {
+		server := newRedirectServer(t, "/private/config.json")
+
+		_, _, err := FileReaderFromPath(
+			context.Background(),
+			server.URL+"/config.json",
+			DownloadFileWithHTTPClient(doerFunc(http.DefaultClient.Do)),
+			DownloadFileExcludingPaths([]string{"/private/*"}),
+		)
+
+		var redirectErr RedirectError
+		if !errors.As(err, &redirectErr) || redirectErr.Hop != 0 {
+			t.Fatalf("expected RedirectError of the final URL, got: %v", err)
+		}
+	})
+}
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (fn doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestFileReaderFromPath_Limits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow.json":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		case "/chunked.json":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte(`{"foo": "` + strings.Repeat("a", 100) + `"}`))
+		case "/config.txt":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(`{"foo": "bar"}`))
+		case "/vendor.json":
+			w.Header().Set("Content-Type", "application/vnd.api+json; charset=utf-8")
+			_, _ = w.Write([]byte(`{"foo": "bar"}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"foo": "bar"}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Run("content_length_exceeds_max_body_bytes", func(t *testing.T) {
+		_, err := ReadJSONOrYAMLFile[map[string]string](
+			context.Background(),
+			server.URL+"/config.json",
+			DownloadFileWithMaxBodyBytes(5),
+		)
+
+		var tooLargeErr FileTooLargeError
+		if !errors.As(err, &tooLargeErr) || tooLargeErr.Limit != 5 {
+			t.Fatalf("expected FileTooLargeError, got: %v", err)
+		}
+	})
+
+	t.Run("streamed_body_exceeds_max_body_bytes", func(t *testing.T) {
+		_, err := ReadJSONOrYAMLFile[map[string]string](
+			context.Background(),
+			server.URL+"/chunked.json",
+			DownloadFileWithMaxBodyBytes(50),
+		)
+
+		var tooLargeErr FileTooLargeError
+		if !errors.As(err, &tooLargeErr) {
+			t.Fatalf("expected FileTooLargeError, got: %v", err)
+		}
+	})
+
+	t.Run("local_file_exceeds_max_body_bytes", func(t *testing.T) {
+		_, err := ReadJSONOrYAMLFile[map[string]string](
+			context.Background(),
+			"testdata/config.yaml",
+			DownloadFileWithMaxBodyBytes(2),
+		)
+
+		var tooLargeErr FileTooLargeError
+		if !errors.As(err, &tooLargeErr) {
+			t.Fatalf("expected FileTooLargeError, got: %v", err)
+		}
+	})
+
+	t.Run("body_within_max_body_bytes", func(t *testing.T) {
+		result, err := ReadJSONOrYAMLFile[map[string]string](
+			context.Background(),
+			server.URL+"/config.json",
+			DownloadFileWithMaxBodyBytes(int64(len(`{"foo": "bar"}`))),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if (*result)["foo"] != "bar" {
+			t.Fatalf("expected foo to be bar, got: %v", *result)
+		}
+	})
+
+	t.Run("max_int64_max_body_bytes", func(t *testing.T) {
+		for _, filePath := range []string{"testdata/config.json", server.URL + "/config.json", server.URL + "/chunked.json"} {
+			for _, cache := range []FileCache{nil, NewMemoryFileCache()} {
+				result, err := ReadJSONOrYAMLFile[map[string]any](
+					context.Background(),
+					filePath,
+					DownloadFileWithMaxBodyBytes(math.MaxInt64),
+					DownloadFileWithCache(cache),
+				)
+				if err != nil {
+					t.Fatalf("expected nil error of %s, got: %s", filePath, err)
+				}
+
+				if len(*result) == 0 {
+					t.Fatalf("expected the content of %s, got an empty object", filePath)
+				}
+			}
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Now()
+
+		_, err := ReadJSONOrYAMLFile[map[string]string](
+			context.Background(),
+			server.URL+"/slow.json",
+			DownloadFileWithTimeout(50*time.Millisecond),
+		)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+		}
+
+		if time.Since(start) > 2*time.Second {
+			t.Fatal("expected the request to be canceled by the timeout")
+		}
+	})
+
+	t.Run("allowed_content_type", func(t *testing.T) {
+		for _, filePath := range []string{"/config.json", "/vendor.json"} {
+			reader, _, err := FileReaderFromPath(
+				context.Background(),
+				server.URL+filePath,
+				DownloadFileWithAllowedContentTypes([]string{"application/json"}),
+			)
+			if err != nil {
+				t.Fatalf("%s: expected nil error, got: %s", filePath, err)
+			}
+
+			_ = reader.Close()
+		}
+	})
+
+	t.Run("disallowed_content_type", func(t *testing.T) {
+		_, _, err := FileReaderFromPath(
+			context.Background(),
+			server.URL+"/config.txt",
+			DownloadFileWithAllowedContentTypes([]string{"application/json", "application/yaml"}),
+		)
+		if !errors.Is(err, errDisallowedContentType) {
+			t.Fatalf("expected errDisallowedContentType, got: %v", err)
+		}
+	})
+
+	t.Run("wildcard_content_type", func(t *testing.T) {
+		reader, _, err := FileReaderFromPath(
+			context.Background(),
+			server.URL+"/config.txt",
+			DownloadFileWithAllowedContentTypes([]string{"text/*"}),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		_ = reader.Close()
+	})
+}
+
+func TestReadJSONOrYAMLFile_ContentNegotiation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType := r.URL.Query().Get("type")
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		} else {
+			// Prevent the server from detecting the content type.
+			w.Header()["Content-Type"] = nil
+		}
+
+		switch r.URL.Path {
+		case "/api/json":
+			_, _ = w.Write([]byte(`{"foo": "json"}`))
+		case "/api/yaml":
+			_, _ = w.Write([]byte("---\nfoo: yaml\n"))
+		default:
+			_, _ = w.Write([]byte("plain text"))
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		Name     string
+		Path     string
+		Options  []DownloadFileOption
+		Expected string
+	}{
+		{Name: "json_content_type", Path: "/api/json?type=application/json", Expected: "json"},
+		{Name: "json_suffix_content_type", Path: "/api/json?type=application/vnd.foo%2Bjson", Expected: "json"},
+		{Name: "yaml_content_type", Path: "/api/yaml?type=application/yaml", Expected: "yaml"},
+		{Name: "text_yaml_content_type", Path: "/api/yaml?type=text/yaml;charset=utf-8", Expected: "yaml"},
+		{Name: "yaml_suffix_content_type", Path: "/api/yaml?type=application/vnd.foo%2Byaml", Expected: "yaml"},
+		{Name: "sniff_json", Path: "/api/json", Expected: "json"},
+		{Name: "sniff_yaml", Path: "/api/yaml?type=text/plain", Expected: "yaml"},
+		{
+			Name:     "force_format",
+			Path:     "/api/json?type=text/plain",
+			Options:  []DownloadFileOption{DownloadFileWithFormat(FileFormatYAML)},
+			Expected: "json",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := ReadJSONOrYAMLFile[map[string]string](
+				context.Background(),
+				server.URL+tc.Path,
+				tc.Options...,
+			)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			if (*result)["foo"] != tc.Expected {
+				t.Errorf("expected %s, got: %v", tc.Expected, *result)
+			}
+		})
+	}
+
+	t.Run("unknown_format", func(t *testing.T) {
+		_, err := ReadJSONOrYAMLFile[map[string]string](context.Background(), server.URL+"/api/text")
+		if !errors.Is(err, errUnsupportedFilePathExtension) {
+			t.Fatalf("expected errUnsupportedFilePathExtension, got: %v", err)
+		}
+	})
+
+	t.Run("force_format_of_local_file", func(t *testing.T) {
+		reader, ext, err := FileReaderFromPath(
+			context.Background(),
+			"testdata/config.txt",
+			DownloadFileWithFormat(FileFormatJSON),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		_ = reader.Close()
+
+		if ext != ".json" {
+			t.Fatalf("expected .json extension, got: %s", ext)
+		}
+	})
+}
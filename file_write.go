@@ -0,0 +1,215 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	defaultWriteFileIndent = 2
+	defaultWriteFileMode   = 0o644
+)
+
+var errInvalidWriteFileIndent = errors.New("indent must not be negative")
+
+// WriteJSONOrYAMLFile encodes the value to the local file by the file extension.
+//
+// The file is written atomically: the content is written to a temporary file in the same directory,
+// flushed to the disk and renamed to the file path, so readers never see a partial document.
+// The permissions of an existing file are kept. New files are created with 0644 permissions
+// unless configured with [WriteFileWithMode].
+func WriteJSONOrYAMLFile[T any](filePath string, value T, options ...WriteFileOption) error {
+	return WriteMultiJSONOrYAMLFile(filePath, []T{value}, options...)
+}
+
+// WriteMultiJSONOrYAMLFile encodes multiple documents to the local file by the file extension.
+// JSON documents are separated by new lines and YAML documents are separated by "---".
+// The file is written atomically like [WriteJSONOrYAMLFile].
+func WriteMultiJSONOrYAMLFile[T any](filePath string, values []T, options ...WriteFileOption) error {
+	opts := &writeFileOptions{
+		Mode:   defaultWriteFileMode,
+		Indent: defaultWriteFileIndent,
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	if opts.Indent < 0 {
+		return errInvalidWriteFileIndent
+	}
+
+	if filePath == "" {
+		return errFilePathRequired
+	}
+
+	var encode func(writer io.Writer) error
+
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		encode = func(writer io.Writer) error {
+			return encodeJSONDocuments(writer, values, opts.Indent)
+		}
+	case ".yaml", ".yml":
+		encode = func(writer io.Writer) error {
+			return encodeYAMLDocuments(writer, values, max(opts.Indent, 1))
+		}
+	default:
+		return errUnsupportedFilePathExtension
+	}
+
+	return writeFileAtomic(filePath, opts.Mode, encode)
+}
+
+// WriteFileOption abstracts a function to modify file writing options.
+type WriteFileOption func(opts *writeFileOptions)
+
+type writeFileOptions struct {
+	Mode   fs.FileMode
+	Indent int
+}
+
+// WriteFileWithMode sets the permissions of new files. The permissions of existing files are kept.
+func WriteFileWithMode(mode fs.FileMode) WriteFileOption {
+	return func(opts *writeFileOptions) {
+		opts.Mode = mode.Perm()
+	}
+}
+
+// WriteFileWithIndent sets the number of spaces to indent nested values. The default is 2.
+// JSON documents are written in the compact form if the indent is zero.
+func WriteFileWithIndent(indent int) WriteFileOption {
+	return func(opts *writeFileOptions) {
+		opts.Indent = indent
+	}
+}
+
+// writeFileAtomic writes the file with the encode function through a temporary file in the same directory.
+func writeFileAtomic(filePath string, mode fs.FileMode, encode func(writer io.Writer) error) error {
+	// Replace the target of a symbolic link instead of the link itself.
+	if realPath, err := filepath.EvalSymlinks(filePath); err == nil {
+		filePath = realPath
+	}
+
+	info, err := os.Stat(filePath)
+	if err == nil {
+		mode = info.Mode().Perm()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	err = writeTempFile(tempFile, mode, encode)
+
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tempFile.Name(), filePath)
+	}
+
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+
+		return err
+	}
+
+	syncDirectory(filepath.Dir(filePath))
+
+	return nil
+}
+
+func writeTempFile(tempFile *os.File, mode fs.FileMode, encode func(writer io.Writer) error) error {
+	writer := bufio.NewWriter(tempFile)
+
+	err := encode(writer)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	err = tempFile.Chmod(mode)
+	if err != nil {
+		return err
+	}
+
+	return tempFile.Sync()
+}
+
+// syncDirectory flushes the rename to the disk. It's best-effort because some platforms can't sync directories.
+func syncDirectory(dir string) {
+	file, err := os.Open(dir) //nolint:gosec
+	if err != nil {
+		return
+	}
+
+	_ = file.Sync()
+	_ = file.Close()
+}
+
+func encodeJSONDocuments[T any](writer io.Writer, values []T, indent int) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	if indent > 0 {
+		encoder.SetIndent("", strings.Repeat(" ", indent))
+	}
+
+	for i, value := range values {
+		err := encoder.Encode(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode multi-documents to JSON at %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func encodeYAMLDocuments[T any](writer io.Writer, values []T, indent int) error {
+	dumper, err := yaml.NewDumper(writer, yaml.WithIndent(indent))
+	if err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		err := dumper.Dump(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode multi-documents to YAML at %d: %w", i, err)
+		}
+	}
+
+	return dumper.Close()
+}
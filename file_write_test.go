@@ -0,0 +1,190 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+type writeTestConfig struct {
+	Name     string   `json:"name"     yaml:"name"`
+	URL      string   `json:"url"      yaml:"url"`
+	Features []string `json:"features" yaml:"features"`
+}
+
+func TestWriteJSONOrYAMLFile(t *testing.T) {
+	value := writeTestConfig{
+		Name:     "app",
+		URL:      "https://example.com/?a=1&b=2",
+		Features: []string{"auth", "metrics"},
+	}
+
+	for _, ext := range []string{".json", ".yaml", ".yml"} {
+		t.Run(ext, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "config"+ext)
+
+			err := WriteJSONOrYAMLFile(filePath, value)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			result, err := ReadJSONOrYAMLFile[writeTestConfig](context.Background(), filePath)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			if !reflect.DeepEqual(*result, value) {
+				t.Fatalf("expected %+v, got: %+v", value, *result)
+			}
+
+			entries, err := os.ReadDir(filepath.Dir(filePath))
+			if err != nil || len(entries) != 1 {
+				t.Fatalf("expected no temporary files, got: %v, %v", entries, err)
+			}
+		})
+	}
+
+	t.Run("keep_mode", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file permissions aren't supported on Windows")
+		}
+
+		filePath := filepath.Join(t.TempDir(), "config.yaml")
+
+		err := WriteJSONOrYAMLFile(filePath, value, WriteFileWithMode(0o600))
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		err = os.Chmod(filePath, 0o640)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = WriteJSONOrYAMLFile(filePath, writeTestConfig{Name: "updated"})
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		info, err := os.Stat(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Mode().Perm() != 0o640 {
+			t.Fatalf("expected mode 0640, got: %o", info.Mode().Perm())
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		err := WriteJSONOrYAMLFile(filepath.Join(t.TempDir(), "config.toml"), value)
+		if !errors.Is(err, errUnsupportedFilePathExtension) {
+			t.Fatalf("expected errUnsupportedFilePathExtension, got: %v", err)
+		}
+
+		err = WriteJSONOrYAMLFile(filepath.Join(t.TempDir(), "config.json"), value, WriteFileWithIndent(-1))
+		if !errors.Is(err, errInvalidWriteFileIndent) {
+			t.Fatalf("expected errInvalidWriteFileIndent, got: %v", err)
+		}
+
+		filePath := filepath.Join(t.TempDir(), "config.json")
+
+		err = WriteJSONOrYAMLFile(filePath, map[string]any{"invalid": make(chan int)})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+
+		if _, statErr := os.Stat(filePath); !errors.Is(statErr, os.ErrNotExist) {
+			t.Fatalf("expected no file after a failed write, got: %v", statErr)
+		}
+	})
+}
+
+func TestWriteMultiJSONOrYAMLFile(t *testing.T) {
+	values := []writeTestConfig{{Name: "a", Features: []string{}}, {Name: "b", Features: []string{"x"}}}
+
+	for _, ext := range []string{".json", ".yaml"} {
+		t.Run(ext, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "configs"+ext)
+
+			err := WriteMultiJSONOrYAMLFile(filePath, values)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			results, err := ReadMultiFromJSONOrYAMLFile[writeTestConfig](context.Background(), filePath)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			if !reflect.DeepEqual(results, values) {
+				t.Fatalf("expected %+v, got: %+v", values, results)
+			}
+		})
+	}
+}
+
+func TestWriteMultiDocumentStream(t *testing.T) {
+	values := []writeTestConfig{
+		{Name: "a", Features: []string{}},
+		{Name: "b", URL: "http://localhost?a&b", Features: []string{"x"}},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := WriteMultiJSONDocumentStream(&buf, values)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		expected := `{"name":"a","url":"","features":[]}
+{"name":"b","url":"http://localhost?a&b","features":["x"]}
+`
+		if buf.String() != expected {
+			t.Fatalf("expected:\n%s\ngot:\n%s", expected, buf.String())
+		}
+
+		results, err := LoadMultiJSONDocumentStream[writeTestConfig](&buf)
+		if err != nil || !reflect.DeepEqual(results, values) {
+			t.Fatalf("expected %+v, got: %+v, %v", values, results, err)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := WriteMultiYAMLDocumentStream(&buf, values)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if !bytes.Contains(buf.Bytes(), []byte("\n---\n")) {
+			t.Fatalf("expected a document separator, got:\n%s", buf.String())
+		}
+
+		results, err := LoadMultiYAMLDocumentStream[writeTestConfig](&buf)
+		if err != nil || !reflect.DeepEqual(results, values) {
+			t.Fatalf("expected %+v, got: %+v, %v", values, results, err)
+		}
+	})
+}
@@ -16,7 +16,9 @@ package goutils
 
 import (
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -30,6 +32,57 @@ type Doer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// RequestRejectedError occurs when a [Doer] middleware rejects a request without sending it
+// to protect the downstream service, e.g. because of [ErrCircuitOpen], [ErrBulkheadFull] or [ErrRateLimited].
+type RequestRejectedError struct {
+	// The 503 Service Unavailable error of the request.
+	HTTPError *httperror.HTTPError
+	// The reason of the rejection.
+	Err error
+}
+
+// Error implements the error interface for RequestRejectedError.
+func (e RequestRejectedError) Error() string {
+	return "request rejected: " + e.Err.Error()
+}
+
+// Unwrap returns the HTTP error and the reason of the rejection.
+func (e RequestRejectedError) Unwrap() []error {
+	return []error{e.HTTPError, e.Err}
+}
+
+// newRequestRejectedError creates a [RequestRejectedError] with a service unavailable error of the request.
+func newRequestRejectedError(req *http.Request, reason error) RequestRejectedError {
+	httpErr := httperror.NewServiceUnavailableError()
+	httpErr.Detail = reason.Error()
+
+	if req.URL != nil {
+		httpErr.Instance = req.URL.String()
+	}
+
+	return RequestRejectedError{HTTPError: httpErr, Err: reason}
+}
+
+// requestHostKey returns the lowercase host and port of the URL, with the default port of the scheme if omitted,
+// so limits of example.com and example.com:443 are shared.
+func requestHostKey(requestURL *url.URL) string {
+	host := strings.ToLower(requestURL.Hostname())
+	port := requestURL.Port()
+
+	if port == "" {
+		switch strings.ToLower(requestURL.Scheme) {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		default:
+			return host
+		}
+	}
+
+	return net.JoinHostPort(host, port)
+}
+
 // ExtractHeaders converts the http.Header to string map with lowercase header names.
 func ExtractHeaders(headers http.Header) map[string]string {
 	result := make(map[string]string)
@@ -0,0 +1,220 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultBulkheadMaxConcurrent = 10
+
+// BulkheadStateChangeHook is called when the number of in-flight requests to a host changes, e.g. to record metrics.
+// The host is the host and port of the request URL, e.g. example.com:443.
+type BulkheadStateChangeHook func(host string, inFlight int)
+
+// BulkheadDoer is a [Doer] middleware that limits the number of in-flight requests per host,
+// so a slow host can't exhaust the connections and goroutines of the client.
+//
+// A request is in flight until the body of its response is closed, or until it fails.
+// When the limit of the host is reached, the request waits up to the maximum wait duration for a slot,
+// or is rejected immediately by default with a [RequestRejectedError] of [ErrBulkheadFull].
+// If the context of the request is done while waiting, the error of the context is returned.
+//
+// Hosts are limited by the host and port of the request URL, with the default port of the scheme if omitted,
+// so requests to example.com and example.com:443 share the same limit.
+type BulkheadDoer struct {
+	client  Doer
+	options *bulkheadDoerOptions
+
+	mu    sync.Mutex
+	hosts map[string]*bulkheadHost
+}
+
+// bulkheadHost holds the slots of a host. It's removed when no request references it.
+type bulkheadHost struct {
+	slots chan struct{}
+	refs  int
+}
+
+// NewBulkheadDoer creates a [BulkheadDoer] that sends requests with the client.
+// The client defaults to [http.DefaultClient].
+func NewBulkheadDoer(client Doer, options ...BulkheadDoerOption) *BulkheadDoer {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &BulkheadDoer{
+		client:  client,
+		options: newBulkheadDoerOptions(options),
+		hosts:   map[string]*bulkheadHost{},
+	}
+}
+
+// InFlight returns the number of in-flight requests to the host, which is the host and port, e.g. example.com:443.
+func (d *BulkheadDoer) InFlight(host string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry, ok := d.hosts[strings.ToLower(host)]
+	if !ok {
+		return 0
+	}
+
+	return len(entry.slots)
+}
+
+// Do sends the HTTP request if the host has a free slot.
+func (d *BulkheadDoer) Do(req *http.Request) (*http.Response, error) {
+	host := requestHostKey(req.URL)
+	entry := d.reference(host)
+
+	err := d.acquire(req, entry)
+	if err != nil {
+		d.dereference(host, entry)
+
+		return nil, err
+	}
+
+	d.notify(host, entry)
+
+	release := sync.OnceFunc(func() {
+		<-entry.slots
+
+		d.notify(host, entry)
+		d.dereference(host, entry)
+	})
+
+	resp, err := d.client.Do(req)
+	if err != nil || resp.Body == nil {
+		release()
+
+		return resp, err
+	}
+
+	resp.Body = &cancelReadCloser{ReadCloser: resp.Body, cancel: release}
+
+	return resp, nil
+}
+
+// acquire takes a slot of the host, waiting up to the maximum wait duration.
+func (d *BulkheadDoer) acquire(req *http.Request, entry *bulkheadHost) error {
+	select {
+	case entry.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	if d.options.MaxWait <= 0 {
+		return newRequestRejectedError(req, ErrBulkheadFull)
+	}
+
+	timer := time.NewTimer(d.options.MaxWait)
+	defer timer.Stop()
+
+	select {
+	case entry.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return newRequestRejectedError(req, ErrBulkheadFull)
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+func (d *BulkheadDoer) reference(host string) *bulkheadHost {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry, ok := d.hosts[host]
+	if !ok {
+		entry = &bulkheadHost{slots: make(chan struct{}, d.options.MaxConcurrent)}
+		d.hosts[host] = entry
+	}
+
+	entry.refs++
+
+	return entry
+}
+
+func (d *BulkheadDoer) dereference(host string, entry *bulkheadHost) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entry.refs--
+
+	if entry.refs == 0 {
+		delete(d.hosts, host)
+	}
+}
+
+func (d *BulkheadDoer) notify(host string, entry *bulkheadHost) {
+	if d.options.OnStateChange != nil {
+		d.options.OnStateChange(host, len(entry.slots))
+	}
+}
+
+type bulkheadDoerOptions struct {
+	MaxConcurrent int
+	MaxWait       time.Duration
+	OnStateChange BulkheadStateChangeHook
+}
+
+func newBulkheadDoerOptions(options []BulkheadDoerOption) *bulkheadDoerOptions {
+	result := &bulkheadDoerOptions{
+		MaxConcurrent: defaultBulkheadMaxConcurrent,
+	}
+
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+
+		opt(result)
+	}
+
+	result.MaxConcurrent = max(result.MaxConcurrent, 1)
+
+	return result
+}
+
+// BulkheadDoerOption abstracts a function to configure options of the [BulkheadDoer].
+type BulkheadDoerOption func(opts *bulkheadDoerOptions)
+
+// BulkheadDoerWithMaxConcurrent creates an option to set the maximum number of in-flight requests per host.
+// The default value is 10.
+func BulkheadDoerWithMaxConcurrent(maxConcurrent int) BulkheadDoerOption {
+	return func(opts *bulkheadDoerOptions) {
+		opts.MaxConcurrent = maxConcurrent
+	}
+}
+
+// BulkheadDoerWithMaxWait creates an option to set how long a request waits for a free slot of the host.
+// Requests are rejected immediately by default.
+func BulkheadDoerWithMaxWait(maxWait time.Duration) BulkheadDoerOption {
+	return func(opts *bulkheadDoerOptions) {
+		opts.MaxWait = maxWait
+	}
+}
+
+// BulkheadDoerWithStateChangeHook creates an option to set the hook that is called
+// when a request to a host starts or finishes.
+func BulkheadDoerWithStateChangeHook(hook BulkheadStateChangeHook) BulkheadDoerOption {
+	return func(opts *bulkheadDoerOptions) {
+		opts.OnStateChange = hook
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/relychan/goutils"
+	"github.com/relychan/goutils/httperror"
+)
+
+func newBulkheadClient() goutils.Doer {
+	return doerFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("ok")),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestBulkheadDoer_LimitsPerHost(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+
+	doer := goutils.NewBulkheadDoer(newBulkheadClient(),
+		goutils.BulkheadDoerWithMaxConcurrent(1),
+		goutils.BulkheadDoerWithStateChangeHook(func(host string, inFlight int) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			events = append(events, host+"="+strconv.Itoa(inFlight))
+		}),
+	)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://a.example/api", nil)
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	_, err = doer.Do(req)
+
+	var httpErr *httperror.HTTPError
+
+	if !errors.Is(err, goutils.ErrBulkheadFull) || !errors.As(err, &httpErr) ||
+		httpErr.Status != http.StatusServiceUnavailable || httpErr.Instance != "http://a.example/api" {
+		t.Fatalf("expected a service unavailable error, got: %v", err)
+	}
+
+	otherReq, _ := http.NewRequest(http.MethodGet, "http://b.example/api", nil)
+
+	otherResp, err := doer.Do(otherReq)
+	if err != nil {
+		t.Fatalf("expected other hosts not to be limited, got: %s", err)
+	}
+
+	goutils.CloseResponse(otherResp)
+
+	if doer.InFlight("a.example:80") != 1 || doer.InFlight("b.example:80") != 0 {
+		t.Fatalf("expected 1 in-flight request, got: %d", doer.InFlight("a.example:80"))
+	}
+
+	goutils.CloseResponse(resp)
+	// Closing the body again must not release another slot.
+	_ = resp.Body.Close()
+
+	if doer.InFlight("a.example:80") != 0 {
+		t.Fatalf("expected the slot to be released, got: %d", doer.InFlight("a.example:80"))
+	}
+
+	resp, err = doer.Do(req)
+	if err != nil {
+		t.Fatalf("expected nil error after the slot is released, got: %s", err)
+	}
+
+	goutils.CloseResponse(resp)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assertStrings(t, []string{
+		"a.example:80=1",
+		"b.example:80=1",
+		"b.example:80=0",
+		"a.example:80=0",
+		"a.example:80=1",
+		"a.example:80=0",
+	}, events)
+}
+
+func TestBulkheadDoer_MaxWait(t *testing.T) {
+	doer := goutils.NewBulkheadDoer(newBulkheadClient(),
+		goutils.BulkheadDoerWithMaxConcurrent(1),
+		goutils.BulkheadDoerWithMaxWait(time.Second),
+	)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/api", nil)
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		goutils.CloseResponse(resp)
+	}()
+
+	resp2, err := doer.Do(req)
+	if err != nil {
+		t.Fatalf("expected the request to wait for a slot, got: %s", err)
+	}
+
+	goutils.CloseResponse(resp2)
+
+	doer = goutils.NewBulkheadDoer(newBulkheadClient(),
+		goutils.BulkheadDoerWithMaxConcurrent(1),
+		goutils.BulkheadDoerWithMaxWait(10*time.Millisecond),
+	)
+
+	resp3, _ := doer.Do(req)
+	defer goutils.CloseResponse(resp3)
+
+	_, err = doer.Do(req)
+	if !errors.Is(err, goutils.ErrBulkheadFull) {
+		t.Fatalf("expected ErrBulkheadFull after waiting, got: %v", err)
+	}
+}
+
+func TestBulkheadDoer_ReleasesOnError(t *testing.T) {
+	errConnection := errors.New("connection refused")
+
+	doer := goutils.NewBulkheadDoer(doerFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errConnection
+	}), goutils.BulkheadDoerWithMaxConcurrent(1))
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/api", nil)
+
+	for range 2 {
+		_, err := doer.Do(req)
+		if !errors.Is(err, errConnection) {
+			t.Fatalf("expected the connection error, got: %v", err)
+		}
+	}
+
+	if doer.InFlight("localhost:80") != 0 {
+		t.Fatalf("expected no in-flight requests, got: %d", doer.InFlight("localhost:80"))
+	}
+}
+
+func TestBulkheadDoer_DefaultPort(t *testing.T) {
+	doer := goutils.NewBulkheadDoer(newBulkheadClient(), goutils.BulkheadDoerWithMaxConcurrent(1))
+
+	req, _ := http.NewRequest(http.MethodGet, "https://Example.com/api", nil)
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	defer goutils.CloseResponse(resp)
+
+	if doer.InFlight("example.com:443") != 1 {
+		t.Fatalf("expected 1 in-flight request, got: %d", doer.InFlight("example.com:443"))
+	}
+
+	for _, rawURL := range []string{"https://example.com:443/api", "https://EXAMPLE.COM/other"} {
+		otherReq, _ := http.NewRequest(http.MethodGet, rawURL, nil)
+
+		_, err = doer.Do(otherReq)
+		if !errors.Is(err, goutils.ErrBulkheadFull) {
+			t.Errorf("%s: expected ErrBulkheadFull, got: %v", rawURL, err)
+		}
+	}
+
+	otherReq, _ := http.NewRequest(http.MethodGet, "http://example.com/api", nil)
+
+	otherResp, err := doer.Do(otherReq)
+	if err != nil {
+		t.Fatalf("expected other ports not to be limited, got: %s", err)
+	}
+
+	goutils.CloseResponse(otherResp)
+}
+
+func TestBulkheadDoer_ContextDone(t *testing.T) {
+	doer := goutils.NewBulkheadDoer(newBulkheadClient(),
+		goutils.BulkheadDoerWithMaxConcurrent(1),
+		goutils.BulkheadDoerWithMaxWait(time.Minute),
+	)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/api", nil)
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	defer goutils.CloseResponse(resp)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = doer.Do(req.WithContext(ctx))
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, goutils.ErrBulkheadFull) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+
+	if doer.InFlight("localhost:80") != 1 {
+		t.Fatalf("expected 1 in-flight request, got: %d", doer.InFlight("localhost:80"))
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// CircuitClosed is the state of the circuit breaker that sends requests and counts failures.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen is the state of the circuit breaker that rejects requests until the open duration elapses.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen is the state of the circuit breaker that sends a limited number of trial requests
+	// to check if the downstream service has recovered.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+const (
+	defaultCircuitFailureRatio     = 0.5
+	defaultCircuitMinRequests      = 10
+	defaultCircuitWindow           = 30 * time.Second
+	defaultCircuitOpenDuration     = 30 * time.Second
+	defaultCircuitHalfOpenRequests = 1
+	// circuitWindowBuckets is the number of buckets of the rolling window.
+	circuitWindowBuckets = 10
+)
+
+var defaultCircuitFailureStatusCodes = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// CircuitState represents the state of a [CircuitBreakerDoer].
+type CircuitState string
+
+// CircuitStateChangeHook is called when the state of a [CircuitBreakerDoer] changes, e.g. to record metrics.
+type CircuitStateChangeHook func(from CircuitState, to CircuitState)
+
+// CircuitBreakerDoer is a [Doer] middleware that stops sending requests to a failing downstream service.
+//
+// The circuit is closed at first. It opens when the ratio of failures in the rolling window reaches the failure ratio,
+// after the minimum number of requests. Failures are errors without a response and responses with failure status codes.
+// Requests canceled by the caller aren't counted. While the circuit is open, requests are rejected
+// with a [RequestRejectedError] of [ErrCircuitOpen]. After the open duration, the circuit is half-open
+// and a limited number of trial requests are sent. It closes if all trial requests succeed, or opens again if any fails.
+//
+// The circuit is shared by all requests of the doer, so create a doer for every downstream service.
+type CircuitBreakerDoer struct {
+	client  Doer
+	options *circuitBreakerDoerOptions
+
+	mu                sync.Mutex
+	state             CircuitState
+	generation        uint64
+	openedAt          time.Time
+	buckets           [circuitWindowBuckets]circuitBucket
+	halfOpenInFlight  int
+	halfOpenSucceeded int
+}
+
+// circuitBucket counts the results of a slot of the rolling window.
+type circuitBucket struct {
+	slot      int64
+	successes int
+	failures  int
+}
+
+// NewCircuitBreakerDoer creates a [CircuitBreakerDoer] that sends requests with the client.
+// The client defaults to [http.DefaultClient].
+func NewCircuitBreakerDoer(client Doer, options ...CircuitBreakerDoerOption) *CircuitBreakerDoer {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &CircuitBreakerDoer{
+		client:  client,
+		options: newCircuitBreakerDoerOptions(options),
+		state:   CircuitClosed,
+	}
+}
+
+// State returns the current state of the circuit.
+func (d *CircuitBreakerDoer) State() CircuitState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.state
+}
+
+// Do sends the HTTP request if the circuit allows it and records the result.
+func (d *CircuitBreakerDoer) Do(req *http.Request) (*http.Response, error) {
+	generation, ok := d.acquire(time.Now())
+	if !ok {
+		return nil, newRequestRejectedError(req, ErrCircuitOpen)
+	}
+
+	resp, err := d.client.Do(req)
+
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		d.release(generation)
+	case err != nil:
+		d.record(generation, true, time.Now())
+	default:
+		d.record(generation, slices.Contains(d.options.FailureStatusCodes, resp.StatusCode), time.Now())
+	}
+
+	return resp, err
+}
+
+// acquire reports whether the request can be sent, and returns the generation of the state that allows it.
+func (d *CircuitBreakerDoer) acquire(now time.Time) (uint64, bool) {
+	d.mu.Lock()
+
+	from := d.state
+
+	if d.state == CircuitOpen && now.Sub(d.openedAt) >= d.options.OpenDuration {
+		d.transition(CircuitHalfOpen, now)
+	}
+
+	allowed := true
+
+	switch d.state {
+	case CircuitOpen:
+		allowed = false
+	case CircuitHalfOpen:
+		if d.halfOpenInFlight+d.halfOpenSucceeded >= d.options.HalfOpenRequests {
+			allowed = false
+		} else {
+			d.halfOpenInFlight++
+		}
+	default:
+	}
+
+	generation, to := d.generation, d.state
+
+	d.mu.Unlock()
+	d.notify(from, to)
+
+	return generation, allowed
+}
+
+// release releases the trial request of the generation without recording its result.
+func (d *CircuitBreakerDoer) release(generation uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if generation == d.generation && d.state == CircuitHalfOpen {
+		d.halfOpenInFlight--
+	}
+}
+
+// record records the result of the request. Results of requests allowed by a previous state are ignored.
+func (d *CircuitBreakerDoer) record(generation uint64, failure bool, now time.Time) {
+	d.mu.Lock()
+
+	from := d.state
+
+	if generation == d.generation {
+		switch d.state {
+		case CircuitClosed:
+			d.recordClosed(failure, now)
+		case CircuitHalfOpen:
+			d.halfOpenInFlight--
+
+			if failure {
+				d.transition(CircuitOpen, now)
+			} else {
+				d.halfOpenSucceeded++
+
+				if d.halfOpenSucceeded >= d.options.HalfOpenRequests {
+					d.transition(CircuitClosed, now)
+				}
+			}
+		default:
+		}
+	}
+
+	to := d.state
+
+	d.mu.Unlock()
+	d.notify(from, to)
+}
+
+func (d *CircuitBreakerDoer) recordClosed(failure bool, now time.Time) {
+	bucketDuration := max(d.options.Window/circuitWindowBuckets, 1)
+	slot := now.UnixNano() / int64(bucketDuration)
+	bucket := &d.buckets[slot%circuitWindowBuckets]
+
+	if bucket.slot != slot {
+		*bucket = circuitBucket{slot: slot}
+	}
+
+	if failure {
+		bucket.failures++
+	} else {
+		bucket.successes++
+	}
+
+	var total, failures int
+
+	for _, item := range d.buckets {
+		if item.slot > slot-circuitWindowBuckets {
+			total += item.successes + item.failures
+			failures += item.failures
+		}
+	}
+
+	if total >= d.options.MinRequests && float64(failures) >= d.options.FailureRatio*float64(total) {
+		d.transition(CircuitOpen, now)
+	}
+}
+
+// transition changes the state and resets the counters of the previous state.
+func (d *CircuitBreakerDoer) transition(state CircuitState, now time.Time) {
+	d.state = state
+	d.generation++
+	d.halfOpenInFlight = 0
+	d.halfOpenSucceeded = 0
+
+	switch state {
+	case CircuitOpen:
+		d.openedAt = now
+	case CircuitClosed:
+		d.buckets = [circuitWindowBuckets]circuitBucket{}
+	default:
+	}
+}
+
+// notify calls the state change hook outside of the lock, so the hook can read the state.
+func (d *CircuitBreakerDoer) notify(from CircuitState, to CircuitState) {
+	if from != to && d.options.OnStateChange != nil {
+		d.options.OnStateChange(from, to)
+	}
+}
+
+type circuitBreakerDoerOptions struct {
+	FailureRatio       float64
+	MinRequests        int
+	Window             time.Duration
+	OpenDuration       time.Duration
+	HalfOpenRequests   int
+	FailureStatusCodes []int
+	OnStateChange      CircuitStateChangeHook
+}
+
+func newCircuitBreakerDoerOptions(options []CircuitBreakerDoerOption) *circuitBreakerDoerOptions {
+	result := &circuitBreakerDoerOptions{
+		FailureRatio:       defaultCircuitFailureRatio,
+		MinRequests:        defaultCircuitMinRequests,
+		Window:             defaultCircuitWindow,
+		OpenDuration:       defaultCircuitOpenDuration,
+		HalfOpenRequests:   defaultCircuitHalfOpenRequests,
+		FailureStatusCodes: defaultCircuitFailureStatusCodes,
+	}
+
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+
+		opt(result)
+	}
+
+	result.MinRequests = max(result.MinRequests, 1)
+	result.HalfOpenRequests = max(result.HalfOpenRequests, 1)
+
+	return result
+}
+
+// CircuitBreakerDoerOption abstracts a function to configure options of the [CircuitBreakerDoer].
+type CircuitBreakerDoerOption func(opts *circuitBreakerDoerOptions)
+
+// CircuitBreakerDoerWithFailureRatio creates an option to set the ratio of failures between 0 and 1
+// in the rolling window that opens the circuit. The default value is 0.5.
+func CircuitBreakerDoerWithFailureRatio(ratio float64) CircuitBreakerDoerOption {
+	return func(opts *circuitBreakerDoerOptions) {
+		opts.FailureRatio = ratio
+	}
+}
+
+// CircuitBreakerDoerWithMinRequests creates an option to set the minimum number of requests in the rolling window
+// before the failure ratio is evaluated. The default value is 10.
+func CircuitBreakerDoerWithMinRequests(minRequests int) CircuitBreakerDoerOption {
+	return func(opts *circuitBreakerDoerOptions) {
+		opts.MinRequests = minRequests
+	}
+}
+
+// CircuitBreakerDoerWithWindow creates an option to set the duration of the rolling window that counts failures.
+// The window is divided into 10 buckets, so results expire bucket by bucket. The default value is 30s.
+func CircuitBreakerDoerWithWindow(window time.Duration) CircuitBreakerDoerOption {
+	return func(opts *circuitBreakerDoerOptions) {
+		opts.Window = window
+	}
+}
+
+// CircuitBreakerDoerWithOpenDuration creates an option to set how long the circuit stays open
+// before trial requests are sent. The default value is 30s.
+func CircuitBreakerDoerWithOpenDuration(duration time.Duration) CircuitBreakerDoerOption {
+	return func(opts *circuitBreakerDoerOptions) {
+		opts.OpenDuration = duration
+	}
+}
+
+// CircuitBreakerDoerWithHalfOpenRequests creates an option to set the number of trial requests of the half-open state
+// that must succeed to close the circuit. The default value is 1.
+func CircuitBreakerDoerWithHalfOpenRequests(requests int) CircuitBreakerDoerOption {
+	return func(opts *circuitBreakerDoerOptions) {
+		opts.HalfOpenRequests = requests
+	}
+}
+
+// CircuitBreakerDoerWithFailureStatusCodes creates an option to set the status codes of responses that count as failures.
+// The default values are 500, 502, 503 and 504.
+func CircuitBreakerDoerWithFailureStatusCodes(statusCodes ...int) CircuitBreakerDoerOption {
+	return func(opts *circuitBreakerDoerOptions) {
+		opts.FailureStatusCodes = statusCodes
+	}
+}
+
+// CircuitBreakerDoerWithStateChangeHook creates an option to set the hook that is called when the state changes.
+func CircuitBreakerDoerWithStateChangeHook(hook CircuitStateChangeHook) CircuitBreakerDoerOption {
+	return func(opts *circuitBreakerDoerOptions) {
+		opts.OnStateChange = hook
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/relychan/goutils"
+	"github.com/relychan/goutils/httperror"
+)
+
+func sendCircuitRequests(t *testing.T, doer goutils.Doer, count int) []error {
+	t.Helper()
+
+	errs := make([]error, 0, count)
+
+	for range count {
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost/api", nil)
+
+		resp, err := doer.Do(req)
+		if err == nil {
+			goutils.CloseResponse(resp)
+		}
+
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+func TestCircuitBreakerDoer_Transitions(t *testing.T) {
+	var transitions []string
+
+	client := &sequenceDoer{statuses: []int{
+		http.StatusOK,
+		http.StatusInternalServerError,
+		http.StatusOK,
+		http.StatusServiceUnavailable,
+		http.StatusServiceUnavailable,
+		http.StatusOK,
+	}}
+
+	doer := goutils.NewCircuitBreakerDoer(client,
+		goutils.CircuitBreakerDoerWithMinRequests(4),
+		goutils.CircuitBreakerDoerWithOpenDuration(20*time.Millisecond),
+		goutils.CircuitBreakerDoerWithStateChangeHook(func(from goutils.CircuitState, to goutils.CircuitState) {
+			transitions = append(transitions, string(from)+"->"+string(to))
+		}),
+	)
+
+	errs := sendCircuitRequests(t, doer, 4)
+	if slices.ContainsFunc(errs, func(err error) bool { return err != nil }) || doer.State() != goutils.CircuitOpen {
+		t.Fatalf("expected the circuit to open after 2 of 4 failures, got: %s, %v", doer.State(), errs)
+	}
+
+	errs = sendCircuitRequests(t, doer, 1)
+
+	var rejectedErr goutils.RequestRejectedError
+
+	var httpErr *httperror.HTTPError
+
+	if !errors.As(errs[0], &rejectedErr) || !errors.Is(errs[0], goutils.ErrCircuitOpen) ||
+		!errors.As(errs[0], &httpErr) || httpErr.Status != http.StatusServiceUnavailable ||
+		httpErr.Instance != "http://localhost/api" {
+		t.Fatalf("expected a service unavailable error, got: %v", errs[0])
+	}
+
+	if len(client.bodies) != 4 {
+		t.Fatalf("expected rejected requests not to be sent, got: %d", len(client.bodies))
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	// The trial request fails and opens the circuit again.
+	sendCircuitRequests(t, doer, 1)
+
+	if doer.State() != goutils.CircuitOpen {
+		t.Fatalf("expected the circuit to open again, got: %s", doer.State())
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	sendCircuitRequests(t, doer, 1)
+
+	if doer.State() != goutils.CircuitClosed {
+		t.Fatalf("expected the circuit to close, got: %s", doer.State())
+	}
+
+	assertStrings(t, []string{
+		"closed->open",
+		"open->half-open",
+		"half-open->open",
+		"open->half-open",
+		"half-open->closed",
+	}, transitions)
+}
+
+func TestCircuitBreakerDoer_HalfOpenRequests(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	failing := true
+	client := doerFunc(func(*http.Request) (*http.Response, error) {
+		if failing {
+			return nil, errors.New("connection refused")
+		}
+
+		started <- struct{}{}
+		<-release
+
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	doer := goutils.NewCircuitBreakerDoer(client,
+		goutils.CircuitBreakerDoerWithMinRequests(1),
+		goutils.CircuitBreakerDoerWithOpenDuration(10*time.Millisecond),
+	)
+
+	sendCircuitRequests(t, doer, 1)
+
+	if doer.State() != goutils.CircuitOpen {
+		t.Fatalf("expected the circuit to open on connection errors, got: %s", doer.State())
+	}
+
+	failing = false
+
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan []error)
+
+	go func() {
+		done <- sendCircuitRequests(t, doer, 1)
+	}()
+
+	<-started
+
+	// Only one trial request is allowed while the circuit is half-open.
+	errs := sendCircuitRequests(t, doer, 1)
+	if !errors.Is(errs[0], goutils.ErrCircuitOpen) || doer.State() != goutils.CircuitHalfOpen {
+		t.Fatalf("expected the second trial request to be rejected, got: %s, %v", doer.State(), errs[0])
+	}
+
+	close(release)
+
+	if errs := <-done; errs[0] != nil || doer.State() != goutils.CircuitClosed {
+		t.Fatalf("expected the circuit to close, got: %s, %v", doer.State(), errs[0])
+	}
+}
+
+func TestCircuitBreakerDoer_IgnoresCanceledRequests(t *testing.T) {
+	client := doerFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, req.Context().Err()
+	})
+
+	doer := goutils.NewCircuitBreakerDoer(client, goutils.CircuitBreakerDoerWithMinRequests(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/api", nil)
+
+	_, err := doer.Do(req)
+	if !errors.Is(err, context.Canceled) || doer.State() != goutils.CircuitClosed {
+		t.Fatalf("expected canceled requests not to be counted, got: %s, %v", doer.State(), err)
+	}
+}
+
+func TestCircuitBreakerDoer_RollingWindow(t *testing.T) {
+	client := &sequenceDoer{statuses: []int{http.StatusBadGateway, http.StatusOK}}
+
+	doer := goutils.NewCircuitBreakerDoer(client,
+		goutils.CircuitBreakerDoerWithMinRequests(2),
+		goutils.CircuitBreakerDoerWithFailureRatio(1),
+		goutils.CircuitBreakerDoerWithWindow(50*time.Millisecond),
+	)
+
+	sendCircuitRequests(t, doer, 1)
+
+	// The failure expires before the next request, so the ratio isn't evaluated.
+	time.Sleep(60 * time.Millisecond)
+
+	client.statuses = []int{http.StatusBadGateway}
+
+	sendCircuitRequests(t, doer, 1)
+
+	if doer.State() != goutils.CircuitClosed {
+		t.Fatalf("expected expired failures not to be counted, got: %s", doer.State())
+	}
+
+	sendCircuitRequests(t, doer, 1)
+
+	if doer.State() != goutils.CircuitOpen {
+		t.Fatalf("expected the circuit to open, got: %s", doer.State())
+	}
+}
+
+func TestCircuitBreakerDoer_FailureStatusCodes(t *testing.T) {
+	client := &sequenceDoer{statuses: []int{http.StatusTooManyRequests, http.StatusInternalServerError}}
+
+	doer := goutils.NewCircuitBreakerDoer(client,
+		goutils.CircuitBreakerDoerWithMinRequests(1),
+		goutils.CircuitBreakerDoerWithFailureStatusCodes(http.StatusTooManyRequests),
+	)
+
+	sendCircuitRequests(t, doer, 1)
+
+	if doer.State() != goutils.CircuitOpen {
+		t.Fatalf("expected 429 to count as a failure, got: %s", doer.State())
+	}
+}
+
+func assertStrings(t *testing.T, expected []string, actual []string) {
+	t.Helper()
+
+	if !slices.Equal(expected, actual) {
+		t.Fatalf("expected %v, got: %v", expected, actual)
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/relychan/goutils/httpheader"
+)
+
+const (
+	defaultRateLimitRequests = 10
+	defaultRateLimitInterval = time.Second
+	// unixResetThreshold is the minimum value of X-RateLimit-Reset headers in Unix seconds.
+	// Smaller values are delta-seconds.
+	unixResetThreshold = 1_000_000_000
+	// maxDurationSeconds is the maximum number of seconds of a duration.
+	maxDurationSeconds = float64(math.MaxInt64 / int64(time.Second))
+	// minRateLimitSweepBuckets is the minimum number of buckets before idle buckets are removed.
+	minRateLimitSweepBuckets = 64
+)
+
+// RateLimitKeyFunc returns the key of the request whose limit applies, e.g. the host or an API key.
+type RateLimitKeyFunc func(req *http.Request) string
+
+// RateLimitDoer is a [Doer] middleware that limits the rate of requests with a token bucket per key.
+// The key of a request is its host by default, with the default port of the scheme if omitted,
+// so requests to example.com and example.com:443 share the same bucket.
+//
+// A request takes a token from the bucket of its key. When the bucket is empty, the request waits
+// until a token is refilled or the request context is done, or is rejected immediately in fail-fast mode
+// with a [RequestRejectedError] of [ErrRateLimited].
+//
+// The limits adapt to the quota of the server. The RateLimit-Remaining or X-RateLimit-Remaining header
+// caps the available tokens, and the remaining quota is spread over the RateLimit-Reset or X-RateLimit-Reset duration
+// if it's lower than the configured rate. Requests are paused until the reset if the quota is exhausted,
+// or until the Retry-After duration of a 429 Too Many Requests response.
+// X-RateLimit-Reset headers may be delta-seconds or Unix seconds.
+type RateLimitDoer struct {
+	client  Doer
+	options *rateLimitDoerOptions
+
+	mu      sync.Mutex
+	buckets map[string]*rateLimitBucket
+	// Idle buckets are removed when the number of buckets reaches sweepAt.
+	sweepAt int
+}
+
+// rateLimitBucket is the token bucket of a key.
+type rateLimitBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// The burst and rate are adapted from response headers until the reset time.
+	burst   float64
+	rate    float64
+	resetAt time.Time
+	// No token is taken until the time, e.g. after a 429 response.
+	blockedUntil time.Time
+}
+
+// NewRateLimitDoer creates a [RateLimitDoer] that sends requests with the client.
+// The client defaults to [http.DefaultClient].
+func NewRateLimitDoer(client Doer, options ...RateLimitDoerOption) *RateLimitDoer {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &RateLimitDoer{
+		client:  client,
+		options: newRateLimitDoerOptions(options),
+		buckets: map[string]*rateLimitBucket{},
+		sweepAt: minRateLimitSweepBuckets,
+	}
+}
+
+// Do sends the HTTP request when the bucket of its key has a token, and adapts the limits from the response.
+func (d *RateLimitDoer) Do(req *http.Request) (*http.Response, error) {
+	key := d.options.KeyFunc(req)
+
+	for {
+		delay := d.take(key, time.Now())
+		if delay <= 0 {
+			break
+		}
+
+		if d.options.FailFast {
+			return nil, newRequestRejectedError(req, ErrRateLimited)
+		}
+
+		err := sleepWithContext(req.Context(), delay)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := d.client.Do(req)
+	if err == nil {
+		d.adapt(key, resp, time.Now())
+	}
+
+	return resp, err
+}
+
+// take takes a token of the key. It returns the delay until a token may be available if the bucket is empty.
+func (d *RateLimitDoer) take(key string, now time.Time) time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	bucket := d.bucket(key, now)
+
+	if now.Before(bucket.blockedUntil) {
+		return bucket.blockedUntil.Sub(now)
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+
+		return 0
+	}
+
+	return max(time.Duration((1-bucket.tokens)/bucket.rate*float64(time.Second)), 1)
+}
+
+// adapt adapts the bucket of the key from the rate limit headers and the status of the response.
+func (d *RateLimitDoer) adapt(key string, resp *http.Response, now time.Time) {
+	limit, hasLimit := parseRateLimitHeader(resp.Header, httpheader.RateLimitLimit, httpheader.XRatelimitLimit)
+	remaining, hasRemaining := parseRateLimitHeader(resp.Header, httpheader.RateLimitRemaining, httpheader.XRatelimitRemaining)
+	reset, hasReset := parseRateLimitReset(resp.Header, now)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	bucket := d.bucket(key, now)
+
+	if hasRemaining {
+		bucket.tokens = min(bucket.tokens, remaining)
+	}
+
+	if hasReset && reset > 0 {
+		if hasLimit {
+			bucket.burst = max(min(bucket.burst, limit), 1)
+			bucket.tokens = min(bucket.tokens, bucket.burst)
+		}
+
+		switch {
+		case hasRemaining && remaining < 1:
+			bucket.blockedUntil = later(bucket.blockedUntil, now.Add(reset))
+		case hasRemaining:
+			bucket.rate = min(bucket.rate, remaining/reset.Seconds())
+		default:
+		}
+
+		bucket.resetAt = now.Add(reset)
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+
+	bucket.tokens = 0
+
+	delay, ok := parseRetryAfter(resp.Header.Get(httpheader.RetryAfter), now)
+
+	switch {
+	case ok:
+	case hasReset:
+		delay = reset
+	default:
+		delay = time.Duration(float64(time.Second) / bucket.rate)
+	}
+
+	bucket.blockedUntil = later(bucket.blockedUntil, now.Add(delay))
+}
+
+// bucket returns the refilled bucket of the key. Idle buckets are removed because a new bucket is the same
+// as a full one. They're removed when a bucket is created and the number of buckets has doubled since
+// the last sweep, so the cost of the sweep is amortized over the created buckets.
+func (d *RateLimitDoer) bucket(key string, now time.Time) *rateLimitBucket {
+	bucket, ok := d.buckets[key]
+	if ok {
+		d.refill(bucket, now)
+
+		return bucket
+	}
+
+	if len(d.buckets) >= d.sweepAt {
+		d.sweep(now)
+		d.sweepAt = max(2*len(d.buckets), minRateLimitSweepBuckets)
+	}
+
+	bucket = &rateLimitBucket{
+		tokens:    float64(d.options.Burst),
+		updatedAt: now,
+		burst:     float64(d.options.Burst),
+		rate:      d.options.Rate,
+	}
+	d.buckets[key] = bucket
+
+	return bucket
+}
+
+// sweep removes the buckets that are full and aren't blocked or adapted.
+func (d *RateLimitDoer) sweep(now time.Time) {
+	for key, bucket := range d.buckets {
+		d.refill(bucket, now)
+
+		if bucket.tokens >= bucket.burst && !now.Before(bucket.blockedUntil) && bucket.resetAt.IsZero() {
+			delete(d.buckets, key)
+		}
+	}
+}
+
+// refill adds the tokens of the elapsed time, and restores the configured limits after the reset time.
+func (d *RateLimitDoer) refill(bucket *rateLimitBucket, now time.Time) {
+	if !bucket.resetAt.IsZero() && !now.Before(bucket.resetAt) {
+		bucket.burst = float64(d.options.Burst)
+		bucket.rate = d.options.Rate
+		bucket.resetAt = time.Time{}
+	}
+
+	if elapsed := now.Sub(bucket.updatedAt); elapsed > 0 {
+		bucket.tokens = min(bucket.burst, bucket.tokens+elapsed.Seconds()*bucket.rate)
+		bucket.updatedAt = now
+	}
+}
+
+// parseRateLimitHeader parses the first number of the first present header.
+// Values of RateLimit headers may have parameters, e.g. "100, 100;w=60".
+func parseRateLimitHeader(header http.Header, names ...string) (float64, bool) {
+	for _, name := range names {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+
+		value, _, _ = strings.Cut(value, ",")
+		value, _, _ = strings.Cut(value, ";")
+
+		number, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+
+		return float64(number), true
+	}
+
+	return 0, false
+}
+
+// parseRateLimitReset parses the duration until the quota resets from the RateLimit-Reset header in delta-seconds,
+// or the X-RateLimit-Reset header in delta-seconds or Unix seconds.
+func parseRateLimitReset(header http.Header, now time.Time) (time.Duration, bool) {
+	reset, ok := parseRateLimitHeader(header, httpheader.RateLimitReset)
+	if !ok {
+		reset, ok = parseRateLimitHeader(header, httpheader.XRatelimitReset)
+		if !ok {
+			return 0, false
+		}
+
+		if reset >= unixResetThreshold {
+			return max(time.Unix(int64(min(reset, maxDurationSeconds)), 0).Sub(now), 0), true
+		}
+	}
+
+	return time.Duration(min(reset, maxDurationSeconds)) * time.Second, true
+}
+
+func later(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+type rateLimitDoerOptions struct {
+	Requests int
+	Interval time.Duration
+	Burst    int
+	KeyFunc  RateLimitKeyFunc
+	FailFast bool
+	// The number of tokens that are refilled per second.
+	Rate float64
+}
+
+func newRateLimitDoerOptions(options []RateLimitDoerOption) *rateLimitDoerOptions {
+	result := &rateLimitDoerOptions{
+		Requests: defaultRateLimitRequests,
+		Interval: defaultRateLimitInterval,
+	}
+
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+
+		opt(result)
+	}
+
+	if result.Requests <= 0 || result.Interval <= 0 {
+		result.Requests = defaultRateLimitRequests
+		result.Interval = defaultRateLimitInterval
+	}
+
+	result.Rate = float64(result.Requests) / result.Interval.Seconds()
+
+	if result.Burst <= 0 {
+		result.Burst = result.Requests
+	}
+
+	if result.KeyFunc == nil {
+		result.KeyFunc = rateLimitKeyByHost
+	}
+
+	return result
+}
+
+func rateLimitKeyByHost(req *http.Request) string {
+	return requestHostKey(req.URL)
+}
+
+// RateLimitDoerOption abstracts a function to configure options of the [RateLimitDoer].
+type RateLimitDoerOption func(opts *rateLimitDoerOptions)
+
+// RateLimitDoerWithLimit creates an option to set the number of requests per interval of every key,
+// which is also the burst size. The default value is 10 requests per second.
+func RateLimitDoerWithLimit(requests int, interval time.Duration) RateLimitDoerOption {
+	return func(opts *rateLimitDoerOptions) {
+		opts.Requests = requests
+		opts.Interval = interval
+	}
+}
+
+// RateLimitDoerWithBurst creates an option to set the maximum number of requests that are sent at once
+// after the bucket is refilled. The default value is the number of requests per interval.
+func RateLimitDoerWithBurst(burst int) RateLimitDoerOption {
+	return func(opts *rateLimitDoerOptions) {
+		opts.Burst = burst
+	}
+}
+
+// RateLimitDoerWithKeyFunc creates an option to set the function that returns the key of requests.
+// Requests with the same key share a bucket. The default key is the host of the request URL.
+func RateLimitDoerWithKeyFunc(keyFunc RateLimitKeyFunc) RateLimitDoerOption {
+	return func(opts *rateLimitDoerOptions) {
+		opts.KeyFunc = keyFunc
+	}
+}
+
+// RateLimitDoerWithFailFast creates an option to reject requests immediately when the bucket is empty
+// instead of waiting for a token.
+func RateLimitDoerWithFailFast(failFast bool) RateLimitDoerOption {
+	return func(opts *rateLimitDoerOptions) {
+		opts.FailFast = failFast
+	}
+}
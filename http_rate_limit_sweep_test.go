@@ -0,0 +1,52 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimitDoer_SweepIdleBuckets(t *testing.T) {
+	doer := NewRateLimitDoer(nil, RateLimitDoerWithLimit(1, time.Second))
+	now := time.Now()
+
+	for i := range minRateLimitSweepBuckets {
+		doer.take("old-"+strconv.Itoa(i), now)
+	}
+
+	// The buckets are empty, so they're kept by the first sweep.
+	doer.take("new-0", now)
+	assertEqual(t, minRateLimitSweepBuckets+1, len(doer.buckets))
+	assertEqual(t, 2*minRateLimitSweepBuckets, doer.sweepAt)
+
+	// The old buckets are full after a minute, but aren't removed until the number of buckets doubles.
+	now = now.Add(time.Minute)
+
+	for i := 1; i < minRateLimitSweepBuckets; i++ {
+		doer.take("new-"+strconv.Itoa(i), now)
+	}
+
+	assertEqual(t, 2*minRateLimitSweepBuckets, len(doer.buckets))
+
+	doer.take("last", now)
+	assertEqual(t, minRateLimitSweepBuckets, len(doer.buckets))
+	assertEqual(t, 2*(minRateLimitSweepBuckets-1), doer.sweepAt)
+
+	if _, ok := doer.buckets["old-0"]; ok {
+		t.Fatal("expected the idle bucket to be removed")
+	}
+}
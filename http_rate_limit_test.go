@@ -0,0 +1,252 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/relychan/goutils"
+	"github.com/relychan/goutils/httperror"
+	"github.com/relychan/goutils/httpheader"
+)
+
+func sendRateLimitRequest(doer goutils.Doer, rawURL string) error {
+	req, _ := http.NewRequest(http.MethodGet, rawURL, nil)
+
+	resp, err := doer.Do(req)
+	if err == nil {
+		goutils.CloseResponse(resp)
+	}
+
+	return err
+}
+
+func TestRateLimitDoer_FailFast(t *testing.T) {
+	client := &sequenceDoer{statuses: []int{http.StatusOK}}
+
+	doer := goutils.NewRateLimitDoer(client,
+		goutils.RateLimitDoerWithLimit(2, time.Minute),
+		goutils.RateLimitDoerWithFailFast(true),
+	)
+
+	for range 2 {
+		err := sendRateLimitRequest(doer, "http://a.example/api")
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+	}
+
+	err := sendRateLimitRequest(doer, "http://a.example/api")
+
+	var rejectedErr goutils.RequestRejectedError
+
+	var httpErr *httperror.HTTPError
+
+	if !errors.As(err, &rejectedErr) || !errors.Is(err, goutils.ErrRateLimited) ||
+		!errors.As(err, &httpErr) || httpErr.Status != http.StatusServiceUnavailable {
+		t.Fatalf("expected a rate limited error, got: %v", err)
+	}
+
+	err = sendRateLimitRequest(doer, "http://b.example/api")
+	if err != nil {
+		t.Fatalf("expected other hosts not to be limited, got: %s", err)
+	}
+
+	if len(client.bodies) != 3 {
+		t.Fatalf("expected rejected requests not to be sent, got: %d", len(client.bodies))
+	}
+}
+
+func TestRateLimitDoer_KeyFunc(t *testing.T) {
+	client := &sequenceDoer{statuses: []int{http.StatusOK}}
+
+	doer := goutils.NewRateLimitDoer(client,
+		goutils.RateLimitDoerWithLimit(1, time.Minute),
+		goutils.RateLimitDoerWithFailFast(true),
+		goutils.RateLimitDoerWithKeyFunc(func(*http.Request) string {
+			return "partner"
+		}),
+	)
+
+	err := sendRateLimitRequest(doer, "http://a.example/api")
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	err = sendRateLimitRequest(doer, "http://b.example/api")
+	if !errors.Is(err, goutils.ErrRateLimited) {
+		t.Fatalf("expected hosts of the same key to share the limit, got: %v", err)
+	}
+}
+
+func TestRateLimitDoer_DefaultKey(t *testing.T) {
+	client := &sequenceDoer{statuses: []int{http.StatusOK}}
+
+	doer := goutils.NewRateLimitDoer(client,
+		goutils.RateLimitDoerWithLimit(1, time.Minute),
+		goutils.RateLimitDoerWithFailFast(true),
+	)
+
+	err := sendRateLimitRequest(doer, "https://Example.com/api")
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	for _, rawURL := range []string{"https://example.com/api", "https://example.com:443/api", "https://EXAMPLE.COM/other"} {
+		err = sendRateLimitRequest(doer, rawURL)
+		if !errors.Is(err, goutils.ErrRateLimited) {
+			t.Errorf("%s: expected the same host to share the limit, got: %v", rawURL, err)
+		}
+	}
+
+	err = sendRateLimitRequest(doer, "http://example.com/api")
+	if err != nil {
+		t.Fatalf("expected other ports not to be limited, got: %s", err)
+	}
+}
+
+func TestRateLimitDoer_Blocks(t *testing.T) {
+	client := &sequenceDoer{statuses: []int{http.StatusOK}}
+
+	doer := goutils.NewRateLimitDoer(client, goutils.RateLimitDoerWithLimit(1, 30*time.Millisecond))
+
+	start := time.Now()
+
+	for range 2 {
+		err := sendRateLimitRequest(doer, "http://localhost/api")
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatalf("expected the second request to wait for a token, got: %s", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	doer = goutils.NewRateLimitDoer(client, goutils.RateLimitDoerWithLimit(1, time.Minute))
+
+	_ = sendRateLimitRequest(doer, "http://localhost/api")
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/api", nil)
+
+	_, err := doer.Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded while waiting, got: %v", err)
+	}
+}
+
+func TestRateLimitDoer_Adapts(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Status   int
+		Header   map[string]string
+		Requests int
+	}{
+		{
+			Name:     "retry_after",
+			Status:   http.StatusTooManyRequests,
+			Header:   map[string]string{httpheader.RetryAfter: "60"},
+			Requests: 1,
+		},
+		{
+			Name:     "too_many_requests",
+			Status:   http.StatusTooManyRequests,
+			Requests: 1,
+		},
+		{
+			Name:   "remaining_exhausted",
+			Status: http.StatusOK,
+			Header: map[string]string{
+				httpheader.RateLimitRemaining: "0",
+				httpheader.RateLimitReset:     "60",
+			},
+			Requests: 1,
+		},
+		{
+			Name:   "unix_reset",
+			Status: http.StatusOK,
+			Header: map[string]string{
+				httpheader.XRatelimitLimit:     "100",
+				httpheader.XRatelimitRemaining: "0",
+				httpheader.XRatelimitReset:     strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10),
+			},
+			Requests: 1,
+		},
+		{
+			Name:   "remaining_paced",
+			Status: http.StatusOK,
+			Header: map[string]string{
+				httpheader.RateLimitLimit:     "100, 100;w=60",
+				httpheader.RateLimitRemaining: "1",
+				httpheader.RateLimitReset:     "60",
+			},
+			Requests: 2,
+		},
+		{
+			Name:     "expired_reset",
+			Status:   http.StatusOK,
+			Header:   map[string]string{httpheader.XRatelimitRemaining: "0", httpheader.XRatelimitReset: "0"},
+			Requests: 1,
+		},
+		{
+			Name:     "without_headers",
+			Status:   http.StatusOK,
+			Requests: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			header := http.Header{}
+
+			for key, value := range tc.Header {
+				header.Set(key, value)
+			}
+
+			client := &sequenceDoer{
+				statuses: []int{tc.Status, http.StatusOK},
+				headers:  []http.Header{header},
+			}
+
+			doer := goutils.NewRateLimitDoer(client,
+				goutils.RateLimitDoerWithLimit(10, time.Minute),
+				goutils.RateLimitDoerWithFailFast(true),
+			)
+
+			for range 20 {
+				err := sendRateLimitRequest(doer, "http://localhost/api")
+				if err != nil {
+					if !errors.Is(err, goutils.ErrRateLimited) {
+						t.Fatalf("expected ErrRateLimited, got: %v", err)
+					}
+
+					break
+				}
+			}
+
+			if len(client.bodies) != tc.Requests {
+				t.Fatalf("expected %d requests, got: %d", tc.Requests, len(client.bodies))
+			}
+		})
+	}
+}
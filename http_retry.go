@@ -0,0 +1,366 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relychan/goutils/httperror"
+	"github.com/relychan/goutils/httpheader"
+)
+
+const (
+	defaultRetryMaxAttempts  = 3
+	defaultRetryInitialDelay = 100 * time.Millisecond
+	defaultRetryMaxDelay     = 10 * time.Second
+	defaultRetryJitter       = 0.5
+)
+
+var (
+	defaultRetryStatusCodes = []int{
+		http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+	// defaultRetryMethods are the idempotent methods of RFC 9110.
+	defaultRetryMethods = []string{
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodTrace,
+		http.MethodPut,
+		http.MethodDelete,
+	}
+)
+
+// RetryError occurs when the [RetryDoer] gives up retrying a request.
+type RetryError struct {
+	// The number of attempts that were sent.
+	Attempts int
+	// The HTTP error of the last response with a retryable status. It's nil if no response was received.
+	HTTPError *httperror.HTTPError
+	// The error of the last attempt that failed without a response, e.g. a connection error,
+	// or the reason to stop waiting for the next attempt, e.g. the context is canceled.
+	Err error
+}
+
+// Error implements the error interface for RetryError.
+func (e RetryError) Error() string {
+	switch {
+	case e.Err != nil:
+		return fmt.Sprintf("request failed after %d attempt(s): %s", e.Attempts, e.Err)
+	case e.HTTPError != nil:
+		return fmt.Sprintf("request failed after %d attempt(s): %d %s", e.Attempts, e.HTTPError.Status, e.HTTPError.Title)
+	default:
+		return fmt.Sprintf("request failed after %d attempt(s)", e.Attempts)
+	}
+}
+
+// Unwrap returns the HTTP error of the last response and the error of the last attempt.
+func (e RetryError) Unwrap() []error {
+	var errs []error
+
+	if e.HTTPError != nil {
+		errs = append(errs, e.HTTPError)
+	}
+
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+
+	return errs
+}
+
+// RetryDoer is a [Doer] middleware that retries requests that fail without a response, e.g. connection errors,
+// or with a retryable status, e.g. 503 Service Unavailable. Attempts are delayed with exponential backoff and jitter,
+// or by the Retry-After header of the response in delta-seconds or an HTTP-date.
+//
+// Only idempotent requests are retried, that are requests with an idempotent method, e.g. GET or PUT,
+// or with an Idempotency-Key or X-Idempotency-Key header. Requests with a body are rewound with GetBody,
+// which is set by [http.NewRequest] for bytes and strings readers. Other requests are sent once.
+//
+// Responses with a retryable status are drained with [CloseResponse] before the next attempt.
+// When it gives up, it returns a [RetryError] that wraps the [httperror.HTTPError] of the last response
+// instead of the response.
+type RetryDoer struct {
+	client  Doer
+	options *retryDoerOptions
+}
+
+// NewRetryDoer creates a [RetryDoer] that sends requests with the client. The client defaults to [http.DefaultClient].
+func NewRetryDoer(client Doer, options ...RetryDoerOption) *RetryDoer {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &RetryDoer{
+		client:  client,
+		options: newRetryDoerOptions(options),
+	}
+}
+
+// Do sends the HTTP request and retries it until it succeeds, returns a non-retryable status
+// or the maximum number of attempts is reached.
+func (d *RetryDoer) Do(req *http.Request) (*http.Response, error) {
+	if !d.isRetryable(req) {
+		return d.client.Do(req)
+	}
+
+	ctx := req.Context()
+
+	var lastHTTPError *httperror.HTTPError
+
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, RetryError{Attempts: attempt - 1, HTTPError: lastHTTPError, Err: err}
+		}
+
+		resp, err := d.client.Do(attemptReq)
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+
+		if err == nil && !slices.Contains(d.options.StatusCodes, resp.StatusCode) {
+			return resp, nil
+		}
+
+		delay := d.backoff(attempt)
+
+		if err == nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get(httpheader.RetryAfter), time.Now()); ok {
+				delay = retryAfter
+			}
+		}
+
+		// Give up if the server asks to wait longer than the maximum delay.
+		if attempt >= d.options.MaxAttempts || delay > d.options.MaxDelay {
+			if err != nil {
+				return nil, RetryError{Attempts: attempt, HTTPError: lastHTTPError, Err: err}
+			}
+
+			return nil, RetryError{Attempts: attempt, HTTPError: httperror.NewHTTPErrorFromResponse(resp)}
+		}
+
+		if err == nil {
+			lastHTTPError = newHTTPErrorWithoutBody(resp)
+
+			CloseResponse(resp)
+		}
+
+		err = sleepWithContext(ctx, delay)
+		if err != nil {
+			return nil, RetryError{Attempts: attempt, HTTPError: lastHTTPError, Err: err}
+		}
+	}
+}
+
+func (d *RetryDoer) isRetryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	if slices.Contains(d.options.Methods, method) {
+		return true
+	}
+
+	_, hasKey := req.Header[httpheader.IdempotencyKey]
+	_, hasXKey := req.Header[httpheader.XIdempotencyKey]
+
+	return hasKey || hasXKey
+}
+
+// backoff returns the exponential delay before the next attempt.
+// Up to the jitter fraction of the delay is subtracted randomly, so clients don't retry at the same time.
+func (d *RetryDoer) backoff(attempt int) time.Duration {
+	delay := d.options.MaxDelay
+
+	if shift := attempt - 1; shift < 62 && d.options.InitialDelay <= d.options.MaxDelay>>shift {
+		delay = d.options.InitialDelay << shift
+	}
+
+	if d.options.Jitter > 0 {
+		// Jitter doesn't need a cryptographically secure random number.
+		delay -= time.Duration(rand.Float64() * d.options.Jitter * float64(delay)) //nolint:gosec
+	}
+
+	return delay
+}
+
+// rewindRequest returns the request of the attempt. Requests of retries are cloned with a new body from GetBody.
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 {
+		return req, nil
+	}
+
+	attemptReq := req.Clone(req.Context())
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind the request body: %w", err)
+		}
+
+		attemptReq.Body = body
+	}
+
+	return attemptReq, nil
+}
+
+// newHTTPErrorWithoutBody creates an [httperror.HTTPError] from the status and request of the response
+// without reading the body, so the body can be drained.
+func newHTTPErrorWithoutBody(resp *http.Response) *httperror.HTTPError {
+	respWithoutBody := *resp
+	respWithoutBody.Body = nil
+
+	return httperror.NewHTTPErrorFromResponse(&respWithoutBody)
+}
+
+// parseRetryAfter parses the value of the Retry-After header in delta-seconds or an HTTP-date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(min(seconds, math.MaxInt64/int64(time.Second))) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
+
+// sleepWithContext waits for the delay or until the context is done.
+func sleepWithContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type retryDoerOptions struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Jitter       float64
+	StatusCodes  []int
+	Methods      []string
+}
+
+func newRetryDoerOptions(options []RetryDoerOption) *retryDoerOptions {
+	result := &retryDoerOptions{
+		MaxAttempts:  defaultRetryMaxAttempts,
+		InitialDelay: defaultRetryInitialDelay,
+		MaxDelay:     defaultRetryMaxDelay,
+		Jitter:       defaultRetryJitter,
+		StatusCodes:  defaultRetryStatusCodes,
+		Methods:      defaultRetryMethods,
+	}
+
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+
+		opt(result)
+	}
+
+	result.MaxAttempts = max(result.MaxAttempts, 1)
+	result.InitialDelay = max(result.InitialDelay, 0)
+	result.MaxDelay = max(result.MaxDelay, result.InitialDelay)
+	result.Jitter = min(max(result.Jitter, 0), 1)
+
+	return result
+}
+
+// RetryDoerOption abstracts a function to configure options of the [RetryDoer].
+type RetryDoerOption func(opts *retryDoerOptions)
+
+// RetryDoerWithMaxAttempts creates an option to set the maximum number of attempts, including the first one.
+// The default value is 3. Requests are sent once if the value is less than 2.
+func RetryDoerWithMaxAttempts(maxAttempts int) RetryDoerOption {
+	return func(opts *retryDoerOptions) {
+		opts.MaxAttempts = maxAttempts
+	}
+}
+
+// RetryDoerWithBackoff creates an option to set the delay before the first retry, which is doubled for every retry
+// up to the maximum delay. The default values are 100ms and 10s.
+// The Retry-After header of responses overrides the delay, but it gives up if the header exceeds the maximum delay.
+func RetryDoerWithBackoff(initialDelay time.Duration, maxDelay time.Duration) RetryDoerOption {
+	return func(opts *retryDoerOptions) {
+		opts.InitialDelay = initialDelay
+		opts.MaxDelay = maxDelay
+	}
+}
+
+// RetryDoerWithJitter creates an option to set the fraction of the delay between 0 and 1 that is randomly subtracted.
+// The default value is 0.5. Zero disables the jitter.
+func RetryDoerWithJitter(jitter float64) RetryDoerOption {
+	return func(opts *retryDoerOptions) {
+		opts.Jitter = jitter
+	}
+}
+
+// RetryDoerWithStatusCodes creates an option to set the retryable status codes of responses.
+// The default values are 408, 429, 500, 502, 503 and 504.
+func RetryDoerWithStatusCodes(statusCodes ...int) RetryDoerOption {
+	return func(opts *retryDoerOptions) {
+		opts.StatusCodes = statusCodes
+	}
+}
+
+// RetryDoerWithMethods creates an option to set the methods that are safe to retry.
+// The default values are the idempotent methods: GET, HEAD, OPTIONS, TRACE, PUT and DELETE.
+// Requests with an Idempotency-Key or X-Idempotency-Key header are retried regardless of the method.
+func RetryDoerWithMethods(methods ...string) RetryDoerOption {
+	return func(opts *retryDoerOptions) {
+		opts.Methods = methods
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/relychan/goutils"
+	"github.com/relychan/goutils/httperror"
+	"github.com/relychan/goutils/httpheader"
+)
+
+// doerFunc adapts a function to the Doer interface.
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (fn doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// sequenceDoer returns the responses in order and records the requests.
+type sequenceDoer struct {
+	statuses []int
+	headers  []http.Header
+	bodies   []string
+	spies    []*closeSpy
+}
+
+func (d *sequenceDoer) Do(req *http.Request) (*http.Response, error) {
+	index := len(d.bodies)
+
+	body := ""
+
+	if req.Body != nil {
+		rawBody, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		body = string(rawBody)
+	}
+
+	d.bodies = append(d.bodies, body)
+
+	status := d.statuses[min(index, len(d.statuses)-1)]
+	header := http.Header{}
+
+	if index < len(d.headers) && d.headers[index] != nil {
+		header = d.headers[index]
+	}
+
+	spy := &closeSpy{ReadCloser: io.NopCloser(strings.NewReader(http.StatusText(status)))}
+	d.spies = append(d.spies, spy)
+
+	return &http.Response{
+		StatusCode:    status,
+		Header:        header,
+		Body:          spy,
+		ContentLength: -1,
+		Request:       req,
+	}, nil
+}
+
+func newFastRetryDoer(client goutils.Doer, options ...goutils.RetryDoerOption) *goutils.RetryDoer {
+	return goutils.NewRetryDoer(client, append([]goutils.RetryDoerOption{
+		goutils.RetryDoerWithBackoff(time.Millisecond, 10*time.Millisecond),
+	}, options...)...)
+}
+
+func TestRetryDoer_RetriesUntilSuccess(t *testing.T) {
+	client := &sequenceDoer{statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK}}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/api", nil)
+
+	resp, err := newFastRetryDoer(client).Do(req)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	defer goutils.CloseResponse(resp)
+
+	if resp.StatusCode != http.StatusOK || len(client.bodies) != 3 {
+		t.Fatalf("expected 200 after 3 attempts, got: %d after %d", resp.StatusCode, len(client.bodies))
+	}
+
+	if !client.spies[0].closed || !client.spies[1].closed || client.spies[2].closed {
+		t.Fatal("expected only bodies of failed attempts to be closed")
+	}
+}
+
+func TestRetryDoer_GivesUp(t *testing.T) {
+	client := &sequenceDoer{statuses: []int{http.StatusServiceUnavailable}}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/api", nil)
+
+	resp, err := newFastRetryDoer(client, goutils.RetryDoerWithMaxAttempts(4)).Do(req)
+	if resp != nil {
+		t.Fatal("expected nil response")
+	}
+
+	var retryErr goutils.RetryError
+
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 4 || len(client.bodies) != 4 {
+		t.Fatalf("expected RetryError after 4 attempts, got: %v", err)
+	}
+
+	var httpErr *httperror.HTTPError
+
+	if !errors.As(err, &httpErr) || httpErr.Status != http.StatusServiceUnavailable ||
+		httpErr.Detail != "Service Unavailable" || httpErr.Instance != "http://localhost/api" {
+		t.Fatalf("expected the last HTTP error, got: %v", httpErr)
+	}
+
+	if err.Error() != "request failed after 4 attempt(s): 503 Service Unavailable" {
+		t.Fatalf("unexpected error message: %s", err)
+	}
+}
+
+func TestRetryDoer_NonRetryableStatus(t *testing.T) {
+	client := &sequenceDoer{statuses: []int{http.StatusNotFound, http.StatusOK}}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/api", nil)
+
+	resp, err := newFastRetryDoer(client).Do(req)
+	if err != nil || resp.StatusCode != http.StatusNotFound || len(client.bodies) != 1 {
+		t.Fatalf("expected the 404 response, got: %v, %v", resp, err)
+	}
+
+	goutils.CloseResponse(resp)
+}
+
+func TestRetryDoer_Idempotency(t *testing.T) {
+	t.Run("post", func(t *testing.T) {
+		client := &sequenceDoer{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}}
+
+		req, _ := http.NewRequest(http.MethodPost, "http://localhost/api", strings.NewReader("data"))
+
+		resp, err := newFastRetryDoer(client).Do(req)
+		if err != nil || resp.StatusCode != http.StatusServiceUnavailable || len(client.bodies) != 1 {
+			t.Fatalf("expected POST requests to be sent once, got: %v, %v", resp, err)
+		}
+
+		goutils.CloseResponse(resp)
+	})
+
+	t.Run("idempotency_key", func(t *testing.T) {
+		client := &sequenceDoer{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}}
+
+		req, _ := http.NewRequest(http.MethodPost, "http://localhost/api", strings.NewReader("data"))
+		req.Header.Set(httpheader.IdempotencyKey, "abc")
+
+		resp, err := newFastRetryDoer(client).Do(req)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got: %v, %v", resp, err)
+		}
+
+		goutils.CloseResponse(resp)
+
+		if len(client.bodies) != 2 || client.bodies[0] != "data" || client.bodies[1] != "data" {
+			t.Fatalf("expected the body to be rewound, got: %v", client.bodies)
+		}
+	})
+
+	t.Run("without_get_body", func(t *testing.T) {
+		client := &sequenceDoer{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}}
+
+		req, _ := http.NewRequest(http.MethodPut, "http://localhost/api", io.NopCloser(strings.NewReader("data")))
+
+		resp, err := newFastRetryDoer(client).Do(req)
+		if err != nil || resp.StatusCode != http.StatusServiceUnavailable || len(client.bodies) != 1 {
+			t.Fatalf("expected requests without GetBody to be sent once, got: %v, %v", resp, err)
+		}
+
+		goutils.CloseResponse(resp)
+	})
+}
+
+func TestRetryDoer_RetryAfter(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		RetryAfter string
+		Attempts   int
+	}{
+		{Name: "delta_seconds", RetryAfter: "0", Attempts: 2},
+		{Name: "http_date", RetryAfter: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), Attempts: 2},
+		{Name: "invalid", RetryAfter: "soon", Attempts: 2},
+		{Name: "exceeds_max_delay", RetryAfter: "3600", Attempts: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			client := &sequenceDoer{
+				statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+				headers:  []http.Header{{httpheader.RetryAfter: []string{tc.RetryAfter}}},
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "http://localhost/api", nil)
+
+			resp, err := newFastRetryDoer(client).Do(req)
+			if len(client.bodies) != tc.Attempts {
+				t.Fatalf("expected %d attempts, got: %d", tc.Attempts, len(client.bodies))
+			}
+
+			if tc.Attempts == 1 {
+				var httpErr *httperror.HTTPError
+
+				if !errors.As(err, &httpErr) || httpErr.Status != http.StatusTooManyRequests {
+					t.Fatalf("expected 429 error, got: %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			goutils.CloseResponse(resp)
+		})
+	}
+}
+
+func TestRetryDoer_ConnectionErrors(t *testing.T) {
+	errConnection := errors.New("connection refused")
+	attempts := 0
+
+	client := doerFunc(func(*http.Request) (*http.Response, error) {
+		attempts++
+
+		return nil, errConnection
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/api", nil)
+
+	_, err := newFastRetryDoer(client).Do(req)
+
+	var retryErr goutils.RetryError
+
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 3 || attempts != 3 || !errors.Is(err, errConnection) {
+		t.Fatalf("expected connection error after 3 attempts, got: %v", err)
+	}
+}
+
+func TestRetryDoer_ContextCanceled(t *testing.T) {
+	client := &sequenceDoer{statuses: []int{http.StatusServiceUnavailable}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost/api", nil)
+
+	doer := goutils.NewRetryDoer(client, goutils.RetryDoerWithBackoff(time.Minute, time.Minute))
+
+	_, err := doer.Do(req)
+
+	var httpErr *httperror.HTTPError
+
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.As(err, &httpErr) || len(client.bodies) != 1 {
+		t.Fatalf("expected deadline exceeded after 1 attempt, got: %v", err)
+	}
+}
@@ -18,8 +18,6 @@ package httpheader
 import (
 	"net/http"
 	"strings"
-
-	"github.com/relychan/goutils"
 )
 
 const (
@@ -43,6 +41,8 @@ const (
 	ContentType = "Content-Type"
 	// DoNotTrack is the constant of the DNT header name.
 	DoNotTrack = "DNT"
+	// IdempotencyKey is the constant of the Idempotency-Key header name.
+	IdempotencyKey = "Idempotency-Key"
 	// IfMatch is the constant of the If-Match header name.
 	IfMatch = "If-Match"
 	// IfModifiedSince is the constant of the If-Modified-Since header name.
@@ -125,6 +125,12 @@ const (
 	P3P = "P3P"
 	// ProxyAuthenticate is the constant of the Proxy-Authenticate header name.
 	ProxyAuthenticate = "Proxy-Authenticate"
+	// RateLimitLimit is the constant of the RateLimit-Limit header name.
+	RateLimitLimit = "RateLimit-Limit"
+	// RateLimitRemaining is the constant of the RateLimit-Remaining header name.
+	RateLimitRemaining = "RateLimit-Remaining"
+	// RateLimitReset is the constant of the RateLimit-Reset header name.
+	RateLimitReset = "RateLimit-Reset"
 	// RetryAfter is the constant of the Retry-After header name.
 	RetryAfter = "Retry-After"
 	// Server is the constant of the Server header name.
@@ -168,6 +174,8 @@ const (
 	XForwardedFor = "X-Forwarded-For"
 	// XRealIP is the constant of the X-Real-IP header.
 	XRealIP = "X-Real-IP"
+	// XIdempotencyKey is the constant of the X-Idempotency-Key header.
+	XIdempotencyKey = "X-Idempotency-Key"
 	// XCSRFToken is the constant of the X-CSRF-Token header.
 	XCSRFToken = "X-CSRF-Token" //nolint:gosec
 	// XRatelimitLimit is the constant of the X-Ratelimit-Limit header.
@@ -227,6 +235,10 @@ const (
 	ContentTypeOctetStream = "application/octet-stream"
 	// ContentTypeGraphQLResponseJSON is the constant for the application/graphql-response+json content type.
 	ContentTypeGraphQLResponseJSON = "application/graphql-response+json"
+	// ContentTypeYAML is the constant for the application/yaml content type.
+	ContentTypeYAML = "application/yaml"
+	// ContentTypeTextYAML is the constant for the text/yaml content type.
+	ContentTypeTextYAML = "text/yaml"
 )
 
 // IsContentTypeXML checks if the content type is XML.
@@ -239,7 +251,7 @@ func IsContentTypeXML(contentType string) bool {
 
 	return strings.EqualFold(mediaType, ContentTypeXML) ||
 		strings.EqualFold(mediaType, ContentTypeTextXML) ||
-		goutils.HasStringSuffixFold(mediaType, "+xml")
+		hasSuffixFold(mediaType, "+xml")
 }
 
 // IsContentTypeJSON checks if the content type is JSON.
@@ -251,17 +263,32 @@ func IsContentTypeJSON(contentType string) bool {
 	mediaType := ExtractBaseMediaType(contentType)
 
 	return strings.EqualFold(mediaType, ContentTypeJSON) ||
-		goutils.HasStringSuffixFold(mediaType, "+json")
+		hasSuffixFold(mediaType, "+json")
+}
+
+// IsContentTypeYAML checks if the content type is YAML.
+func IsContentTypeYAML(contentType string) bool {
+	if contentType == ContentTypeYAML || contentType == ContentTypeTextYAML {
+		return true
+	}
+
+	mediaType := ExtractBaseMediaType(contentType)
+
+	return strings.EqualFold(mediaType, ContentTypeYAML) ||
+		strings.EqualFold(mediaType, ContentTypeTextYAML) ||
+		strings.EqualFold(mediaType, "application/x-yaml") ||
+		strings.EqualFold(mediaType, "text/x-yaml") ||
+		hasSuffixFold(mediaType, "+yaml")
 }
 
 // IsContentTypeText checks if the content type relates to text.
 func IsContentTypeText(contentType string) bool {
-	return goutils.HasStringPrefixFold(contentType, "text/")
+	return hasPrefixFold(contentType, "text/")
 }
 
 // IsContentTypeMultipartForm checks the content type relates to multipart form.
 func IsContentTypeMultipartForm(contentType string) bool {
-	return goutils.HasStringPrefixFold(contentType, "multipart/")
+	return hasPrefixFold(contentType, "multipart/")
 }
 
 // ExtractBaseMediaType extracts the media type from the content type with parameters removed.
@@ -288,3 +315,22 @@ func GetHeaderValue(header http.Header, key string) string {
 
 	return ""
 }
+
+// hasPrefixFold is the same as goutils.HasStringPrefixFold.
+// The root package imports this package so it can't be imported here.
+func hasPrefixFold(input string, prefix string) bool {
+	if len(input) < len(prefix) {
+		return false
+	}
+
+	return strings.EqualFold(input[:len(prefix)], prefix)
+}
+
+// hasSuffixFold is the same as goutils.HasStringSuffixFold.
+func hasSuffixFold(input string, suffix string) bool {
+	if len(input) < len(suffix) {
+		return false
+	}
+
+	return strings.EqualFold(input[len(input)-len(suffix):], suffix)
+}
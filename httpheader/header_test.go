@@ -72,6 +72,33 @@ func TestIsContentTypeJSON(t *testing.T) {
 	}
 }
 
+func TestIsContentTypeYAML(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		expected    bool
+	}{
+		{name: "application/yaml", contentType: ContentTypeYAML, expected: true},
+		{name: "text/yaml", contentType: ContentTypeTextYAML, expected: true},
+		{name: "application/x-yaml", contentType: "application/x-yaml", expected: true},
+		{name: "text/x-yaml with charset", contentType: "text/x-yaml; charset=utf-8", expected: true},
+		{name: "uppercase YAML", contentType: "application/YAML", expected: true},
+		{name: "custom +yaml vendor type", contentType: "application/vnd.oai.openapi+yaml", expected: true},
+		{name: "json is not yaml", contentType: ContentTypeJSON, expected: false},
+		{name: "plain text is not yaml", contentType: ContentTypeTextPlain, expected: false},
+		{name: "empty string", contentType: "", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := IsContentTypeYAML(tc.contentType)
+			if got != tc.expected {
+				t.Errorf("IsContentTypeYAML(%q) = %v, want %v", tc.contentType, got, tc.expected)
+			}
+		})
+	}
+}
+
 func TestIsContentTypeXML(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -15,14 +15,23 @@
 package goutils
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"strings"
 )
 
 // LoadMultiJSONDocumentStream loads multi-document JSON from a reader stream.
-func LoadMultiJSONDocumentStream[T any](reader io.Reader) ([]T, error) {
+// Options such as [DownloadFileWithStrict] and [DownloadFileWithEnvExpansion] apply to every document.
+// Decode errors with options are returned as a [ConfigDecodeError] with the document location.
+func LoadMultiJSONDocumentStream[T any](reader io.Reader, options ...DownloadFileOption) ([]T, error) {
+	if len(options) > 0 {
+		return decodeDocuments[T](context.Background(), reader, "", ".json", newDownloadFileOptions(options), -1)
+	}
+
 	var results []T
 
 	decoder := json.NewDecoder(reader)
@@ -48,3 +57,38 @@ func LoadMultiJSONDocumentStream[T any](reader io.Reader) ([]T, error) {
 
 	return results, nil
 }
+
+// LoadMultiJSONDocumentStreamSeq returns an iterator that decodes multi-document JSON from a reader stream
+// one document at a time, so large streams such as NDJSON aren't loaded into memory at once.
+// The iterator stops after the first error, when the context is canceled, or when the loop breaks.
+func LoadMultiJSONDocumentStreamSeq[T any](
+	ctx context.Context,
+	reader io.Reader,
+	options ...DownloadFileOption,
+) iter.Seq2[T, error] {
+	return decodeDocumentSeq[T](ctx, reader, "", ".json", newDownloadFileOptions(options))
+}
+
+// WriteMultiJSONDocumentStream encodes multi-document JSON to a writer stream, one compact document per line,
+// so the output can be loaded by [LoadMultiJSONDocumentStream].
+func WriteMultiJSONDocumentStream[T any](writer io.Writer, documents []T) error {
+	return encodeJSONDocuments(writer, documents, 0)
+}
+
+// appendJSONPointer appends an escaped reference token to the JSON Pointer (RFC 6901).
+func appendJSONPointer(pointer string, token string) string {
+	if strings.ContainsAny(token, "~/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+	}
+
+	return pointer + "/" + token
+}
+
+// unescapeJSONPointerToken unescapes a reference token of a JSON Pointer (RFC 6901).
+func unescapeJSONPointerToken(token string) string {
+	if !strings.Contains(token, "~") {
+		return token
+	}
+
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
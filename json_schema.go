@@ -0,0 +1,691 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/relychan/goutils/httperror"
+	"go.yaml.in/yaml/v4"
+)
+
+// maxJSONSchemaDepth is the maximum depth of nested schemas and references while validating a value.
+const maxJSONSchemaDepth = 256
+
+var (
+	errJSONSchemaDepthExceeded  = fmt.Errorf("schema depth exceeds the limit of %d", maxJSONSchemaDepth)
+	errUnsupportedJSONSchemaRef = errors.New("only local references of the schema are supported")
+	errInvalidJSONSchemaRef     = errors.New("schema reference doesn't exist")
+	iso8601DurationRegexp       = regexp.MustCompile(
+		`^P(?:\d+W|(?:\d+Y)?(?:\d+M)?(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+(?:[.,]\d+)?S)?)?)$`,
+	)
+)
+
+// JSONSchemaTypes is the list of allowed types of a JSON Schema.
+// A single type is encoded as a string.
+type JSONSchemaTypes []string
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *JSONSchemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+
+	if json.Unmarshal(data, &single) == nil {
+		*t = JSONSchemaTypes{single}
+
+		return nil
+	}
+
+	var list []string
+
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		return err
+	}
+
+	*t = list
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t JSONSchemaTypes) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// JSONSchema represents a practical subset of JSON Schema 2019-09 and 2020-12 to validate documents:
+//
+//   - Types: type, enum.
+//   - Strings: pattern, minLength, maxLength and the uri, date-time, duration and regex formats.
+//   - Numbers: minimum, maximum, exclusiveMinimum, exclusiveMaximum.
+//   - Arrays: items, minItems, maxItems.
+//   - Objects: properties, required, additionalProperties.
+//   - Composition: allOf, anyOf, oneOf and local $ref references to $defs or definitions.
+//
+// Other keywords and unknown formats are ignored. A schema can be decoded from JSON or YAML,
+// including the boolean schemas true and false.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+	Definitions          map[string]*JSONSchema `json:"definitions,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 JSONSchemaTypes        `json:"type,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MaxLength            *int                   `json:"maxLength,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64               `json:"exclusiveMaximum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	MaxItems             *int                   `json:"maxItems,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	AllOf                []*JSONSchema          `json:"allOf,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+
+	// The value of a boolean schema.
+	boolean *bool
+}
+
+// NewBooleanJSONSchema creates a boolean schema. The true schema accepts any value
+// and the false schema rejects every value.
+func NewBooleanJSONSchema(value bool) *JSONSchema {
+	return &JSONSchema{boolean: &value}
+}
+
+// IsBoolean checks if the schema is a boolean schema.
+func (s JSONSchema) IsBoolean() bool {
+	return s.boolean != nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *JSONSchema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true":
+		*s = *NewBooleanJSONSchema(true)
+
+		return nil
+	case "false":
+		*s = *NewBooleanJSONSchema(false)
+
+		return nil
+	default:
+	}
+
+	type plain JSONSchema
+
+	var result plain
+
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return err
+	}
+
+	*s = JSONSchema(result)
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s JSONSchema) MarshalJSON() ([]byte, error) {
+	if s.boolean != nil {
+		return json.Marshal(*s.boolean)
+	}
+
+	type plain JSONSchema
+
+	return json.Marshal(plain(s))
+}
+
+// UnmarshalYAML implements custom deserialization for the yaml.Unmarshaler interface.
+// The YAML document is decoded with the same keywords as JSON.
+func (s *JSONSchema) UnmarshalYAML(value *yaml.Node) error {
+	var raw any
+
+	err := value.Decode(&raw)
+	if err != nil {
+		return err
+	}
+
+	normalized, err := normalizeJSONSchemaValue(raw)
+	if err != nil {
+		return err
+	}
+
+	rawBytes, err := json.Marshal(normalized)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(rawBytes, s)
+}
+
+// Validate validates the value against the schema and returns every violation with its JSON Pointer.
+// The value can be a decoded JSON or YAML document, or any value that can be encoded to JSON.
+func (s *JSONSchema) Validate(value any) []httperror.ValidationError {
+	normalized, err := normalizeJSONSchemaValue(value)
+	if err != nil {
+		return []httperror.ValidationError{{Detail: err.Error()}}
+	}
+
+	validator := &jsonSchemaValidator{
+		root:     s,
+		patterns: map[string]*regexp.Regexp{},
+	}
+
+	validator.validate(s, normalized, "", 0)
+
+	return validator.errors
+}
+
+type jsonSchemaValidator struct {
+	root     *JSONSchema
+	patterns map[string]*regexp.Regexp
+	errors   []httperror.ValidationError
+}
+
+func (jv *jsonSchemaValidator) addError(pointer string, code string, detail string) {
+	jv.errors = append(jv.errors, httperror.ValidationError{
+		Detail:  detail,
+		Pointer: pointer,
+		Code:    code,
+	})
+}
+
+// matches checks if the value is valid against the schema without recording errors.
+func (jv *jsonSchemaValidator) matches(schema *JSONSchema, value any, pointer string, depth int) bool {
+	child := &jsonSchemaValidator{
+		root:     jv.root,
+		patterns: jv.patterns,
+	}
+
+	child.validate(schema, value, pointer, depth)
+
+	return len(child.errors) == 0
+}
+
+func (jv *jsonSchemaValidator) validate(schema *JSONSchema, value any, pointer string, depth int) {
+	if schema == nil {
+		return
+	}
+
+	if depth > maxJSONSchemaDepth {
+		jv.addError(pointer, "$ref", errJSONSchemaDepthExceeded.Error())
+
+		return
+	}
+
+	if schema.boolean != nil {
+		if !*schema.boolean {
+			jv.addError(pointer, "false", "value is not allowed")
+		}
+
+		return
+	}
+
+	if schema.Ref != "" {
+		target, err := jv.resolveRef(schema.Ref)
+		if err != nil {
+			jv.addError(pointer, "$ref", fmt.Sprintf("%s: %s", err, schema.Ref))
+		} else {
+			jv.validate(target, value, pointer, depth+1)
+		}
+	}
+
+	if len(schema.Type) > 0 && !slices.ContainsFunc(schema.Type, func(name string) bool {
+		return isJSONSchemaType(value, name)
+	}) {
+		jv.addError(pointer, "type", fmt.Sprintf(
+			"expected type %s, got %s",
+			strings.Join(schema.Type, " or "),
+			jsonSchemaTypeOf(value),
+		))
+
+		return
+	}
+
+	if len(schema.Enum) > 0 && !jv.isEnumValue(schema.Enum, value) {
+		jv.addError(pointer, "enum", "value must be one of "+formatJSONSchemaEnum(schema.Enum))
+	}
+
+	switch typedValue := value.(type) {
+	case string:
+		jv.validateString(schema, typedValue, pointer)
+	case float64:
+		jv.validateNumber(schema, typedValue, pointer)
+	case []any:
+		jv.validateArray(schema, typedValue, pointer, depth)
+	case map[string]any:
+		jv.validateObject(schema, typedValue, pointer, depth)
+	default:
+	}
+
+	jv.validateComposition(schema, value, pointer, depth)
+}
+
+func (jv *jsonSchemaValidator) validateString(schema *JSONSchema, value string, pointer string) {
+	length := utf8.RuneCountInString(value)
+
+	if schema.MinLength != nil && length < *schema.MinLength {
+		jv.addError(pointer, "minLength", fmt.Sprintf("string length must be at least %d", *schema.MinLength))
+	}
+
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		jv.addError(pointer, "maxLength", fmt.Sprintf("string length must be at most %d", *schema.MaxLength))
+	}
+
+	if schema.Pattern != "" {
+		pattern, err := jv.compilePattern(schema.Pattern)
+
+		switch {
+		case err != nil:
+			jv.addError(pointer, "pattern", fmt.Sprintf("invalid pattern %q: %s", schema.Pattern, err))
+		case !pattern.MatchString(value):
+			jv.addError(pointer, "pattern", fmt.Sprintf("string doesn't match the pattern %q", schema.Pattern))
+		default:
+		}
+	}
+
+	if schema.Format != "" && !isJSONSchemaFormat(schema.Format, value) {
+		jv.addError(pointer, "format", fmt.Sprintf("string isn't a valid %s", schema.Format))
+	}
+}
+
+func (jv *jsonSchemaValidator) validateNumber(schema *JSONSchema, value float64, pointer string) {
+	if schema.Minimum != nil && value < *schema.Minimum {
+		jv.addError(pointer, "minimum", "value must be greater than or equal to "+formatJSONSchemaNumber(*schema.Minimum))
+	}
+
+	if schema.Maximum != nil && value > *schema.Maximum {
+		jv.addError(pointer, "maximum", "value must be less than or equal to "+formatJSONSchemaNumber(*schema.Maximum))
+	}
+
+	if schema.ExclusiveMinimum != nil && value <= *schema.ExclusiveMinimum {
+		jv.addError(
+			pointer,
+			"exclusiveMinimum",
+			"value must be greater than "+formatJSONSchemaNumber(*schema.ExclusiveMinimum),
+		)
+	}
+
+	if schema.ExclusiveMaximum != nil && value >= *schema.ExclusiveMaximum {
+		jv.addError(
+			pointer,
+			"exclusiveMaximum",
+			"value must be less than "+formatJSONSchemaNumber(*schema.ExclusiveMaximum),
+		)
+	}
+}
+
+func (jv *jsonSchemaValidator) validateArray(schema *JSONSchema, value []any, pointer string, depth int) {
+	if schema.MinItems != nil && len(value) < *schema.MinItems {
+		jv.addError(pointer, "minItems", fmt.Sprintf("array must have at least %d items", *schema.MinItems))
+	}
+
+	if schema.MaxItems != nil && len(value) > *schema.MaxItems {
+		jv.addError(pointer, "maxItems", fmt.Sprintf("array must have at most %d items", *schema.MaxItems))
+	}
+
+	if schema.Items == nil {
+		return
+	}
+
+	for i, item := range value {
+		jv.validate(schema.Items, item, appendJSONPointer(pointer, strconv.Itoa(i)), depth+1)
+	}
+}
+
+func (jv *jsonSchemaValidator) validateObject(
+	schema *JSONSchema,
+	value map[string]any,
+	pointer string,
+	depth int,
+) {
+	for _, name := range schema.Required {
+		if _, ok := value[name]; !ok {
+			jv.addError(appendJSONPointer(pointer, name), "required", fmt.Sprintf("missing required property %q", name))
+		}
+	}
+
+	// Sort keys for stable errors.
+	keys := make([]string, 0, len(value))
+
+	for key := range value {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		itemPointer := appendJSONPointer(pointer, key)
+
+		if propertySchema, ok := schema.Properties[key]; ok {
+			jv.validate(propertySchema, value[key], itemPointer, depth+1)
+
+			continue
+		}
+
+		if schema.AdditionalProperties == nil {
+			continue
+		}
+
+		if schema.AdditionalProperties.boolean != nil && !*schema.AdditionalProperties.boolean {
+			jv.addError(itemPointer, "additionalProperties", fmt.Sprintf("additional property %q is not allowed", key))
+
+			continue
+		}
+
+		jv.validate(schema.AdditionalProperties, value[key], itemPointer, depth+1)
+	}
+}
+
+func (jv *jsonSchemaValidator) validateComposition(schema *JSONSchema, value any, pointer string, depth int) {
+	for _, item := range schema.AllOf {
+		jv.validate(item, value, pointer, depth+1)
+	}
+
+	if len(schema.AnyOf) > 0 && !slices.ContainsFunc(schema.AnyOf, func(item *JSONSchema) bool {
+		return jv.matches(item, value, pointer, depth+1)
+	}) {
+		jv.addError(pointer, "anyOf", "value doesn't match any schema of anyOf")
+	}
+
+	if len(schema.OneOf) == 0 {
+		return
+	}
+
+	matched := 0
+
+	for _, item := range schema.OneOf {
+		if jv.matches(item, value, pointer, depth+1) {
+			matched++
+		}
+	}
+
+	if matched != 1 {
+		jv.addError(
+			pointer,
+			"oneOf",
+			fmt.Sprintf("value must match exactly one schema of oneOf, matched %d", matched),
+		)
+	}
+}
+
+// resolveRef resolves a local reference to a schema in the root schema, e.g. "#/$defs/port".
+func (jv *jsonSchemaValidator) resolveRef(ref string) (*JSONSchema, error) {
+	fragment, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, errUnsupportedJSONSchemaRef
+	}
+
+	fragment, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, err
+	}
+
+	if fragment == "" {
+		return jv.root, nil
+	}
+
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, errUnsupportedJSONSchemaRef
+	}
+
+	current := jv.root
+	tokens := strings.Split(fragment[1:], "/")
+
+	for i := 0; i < len(tokens) && current != nil; i++ {
+		current, i = stepJSONSchemaPointer(current, tokens, i)
+	}
+
+	if current == nil {
+		return nil, errInvalidJSONSchemaRef
+	}
+
+	return current, nil
+}
+
+// stepJSONSchemaPointer resolves the reference token at the index and returns the schema
+// and the index of the last consumed token.
+func stepJSONSchemaPointer(schema *JSONSchema, tokens []string, index int) (*JSONSchema, int) {
+	keyword := unescapeJSONPointerToken(tokens[index])
+
+	switch keyword {
+	case "items":
+		return schema.Items, index
+	case "additionalProperties":
+		return schema.AdditionalProperties, index
+	default:
+	}
+
+	if index+1 >= len(tokens) {
+		return nil, index
+	}
+
+	name := unescapeJSONPointerToken(tokens[index+1])
+
+	switch keyword {
+	case "$defs":
+		return schema.Defs[name], index + 1
+	case "definitions":
+		return schema.Definitions[name], index + 1
+	case "properties":
+		return schema.Properties[name], index + 1
+	case "allOf", "anyOf", "oneOf":
+		list := map[string][]*JSONSchema{"allOf": schema.AllOf, "anyOf": schema.AnyOf, "oneOf": schema.OneOf}[keyword]
+
+		position, err := strconv.Atoi(name)
+		if err != nil || position < 0 || position >= len(list) {
+			return nil, index + 1
+		}
+
+		return list[position], index + 1
+	default:
+		return nil, index
+	}
+}
+
+func (jv *jsonSchemaValidator) compilePattern(pattern string) (*regexp.Regexp, error) {
+	if result, ok := jv.patterns[pattern]; ok {
+		return result, nil
+	}
+
+	result, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	jv.patterns[pattern] = result
+
+	return result, nil
+}
+
+func (jv *jsonSchemaValidator) isEnumValue(enum []any, value any) bool {
+	for _, item := range enum {
+		normalized, err := normalizeJSONSchemaValue(item)
+		if err == nil && reflect.DeepEqual(normalized, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isJSONSchemaType(value any, name string) bool {
+	switch name {
+	case "integer":
+		number, ok := value.(float64)
+
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := value.(float64)
+
+		return ok
+	default:
+		return jsonSchemaTypeOf(value) == name
+	}
+}
+
+func jsonSchemaTypeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// isJSONSchemaFormat checks the string against a known format. Unknown formats are annotations only.
+func isJSONSchemaFormat(format string, value string) bool {
+	switch format {
+	case "uri":
+		result, err := url.Parse(value)
+
+		return err == nil && result.Scheme != ""
+	case "date-time":
+		_, err := time.Parse(time.RFC3339Nano, strings.ToUpper(value))
+
+		return err == nil
+	case "regex":
+		_, err := regexp.Compile(value)
+
+		return err == nil
+	case "duration":
+		return iso8601DurationRegexp.MatchString(value) && value != "P" && !strings.HasSuffix(value, "T")
+	default:
+		return true
+	}
+}
+
+// normalizeJSONSchemaValue converts the value to the generic types of decoded JSON documents:
+// maps with string keys, slices of any, float64 numbers, strings, booleans and nil.
+func normalizeJSONSchemaValue(value any) (any, error) { //nolint:cyclop
+	switch typedValue := value.(type) {
+	case nil, bool, string, float64:
+		return value, nil
+	case json.Number:
+		return typedValue.Float64()
+	case int:
+		return float64(typedValue), nil
+	case int32:
+		return float64(typedValue), nil
+	case int64:
+		return float64(typedValue), nil
+	case uint:
+		return float64(typedValue), nil
+	case uint64:
+		return float64(typedValue), nil
+	case float32:
+		return float64(typedValue), nil
+	case map[string]any:
+		result := make(map[string]any, len(typedValue))
+
+		for key, item := range typedValue {
+			normalized, err := normalizeJSONSchemaValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			result[key] = normalized
+		}
+
+		return result, nil
+	case map[any]any:
+		result := make(map[string]any, len(typedValue))
+
+		for key, item := range typedValue {
+			normalized, err := normalizeJSONSchemaValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			result[fmt.Sprint(key)] = normalized
+		}
+
+		return result, nil
+	case []any:
+		result := make([]any, len(typedValue))
+
+		for i, item := range typedValue {
+			normalized, err := normalizeJSONSchemaValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			result[i] = normalized
+		}
+
+		return result, nil
+	default:
+		// Encode other values, e.g. structs, to JSON first.
+		rawBytes, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		var result any
+
+		err = json.Unmarshal(rawBytes, &result)
+
+		return result, err
+	}
+}
+
+func formatJSONSchemaNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatJSONSchemaEnum(enum []any) string {
+	rawBytes, err := json.Marshal(enum)
+	if err != nil {
+		return fmt.Sprint(enum)
+	}
+
+	return string(rawBytes)
+}
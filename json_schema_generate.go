@@ -0,0 +1,427 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft202012 is the URI of the JSON Schema 2020-12 meta-schema.
+const JSONSchemaDraft202012 = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	// durationJSONSchemaPattern matches strings accepted by [ParseDuration]: "0" or units from the biggest to the smallest.
+	durationJSONSchemaPattern = `^(?:0|(?:[0-9]+y)?(?:[0-9]+w)?(?:[0-9]+d)?(?:[0-9]+h)?(?:[0-9]+m)?(?:[0-9]+s)?(?:[0-9]+ms)?)$`
+	// slugJSONSchemaPattern matches strings accepted by [Slug.Validate].
+	slugJSONSchemaPattern = `^[a-zA-Z0-9_-]*$`
+)
+
+var errUnsupportedJSONSchemaType = errors.New("type can't be represented in JSON Schema")
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// knownJSONSchemaTypes maps types with custom decoders to the schemas of values they accept.
+var knownJSONSchemaTypes = map[reflect.Type]func() *JSONSchema{
+	reflect.TypeFor[Duration](): func() *JSONSchema {
+		return &JSONSchema{
+			Type:      JSONSchemaTypes{"string", "null"},
+			Pattern:   durationJSONSchemaPattern,
+			MinLength: new(1),
+		}
+	},
+	reflect.TypeFor[Time](): func() *JSONSchema {
+		return &JSONSchema{Type: JSONSchemaTypes{"string", "null"}, Format: "date-time"}
+	},
+	reflect.TypeFor[Slug](): func() *JSONSchema {
+		return &JSONSchema{Type: JSONSchemaTypes{"string"}, Pattern: slugJSONSchemaPattern}
+	},
+	reflect.TypeFor[AllOrListString]():         allOrListStringJSONSchema,
+	reflect.TypeFor[AllOrListWildcardString](): allOrListStringJSONSchema,
+	reflect.TypeFor[RegexpMatcher](): func() *JSONSchema {
+		return &JSONSchema{Type: JSONSchemaTypes{"string"}, Format: "regex"}
+	},
+	reflect.TypeFor[time.Time](): func() *JSONSchema {
+		return &JSONSchema{Type: JSONSchemaTypes{"string", "null"}, Format: "date-time"}
+	},
+	reflect.TypeFor[json.Number](): func() *JSONSchema {
+		return &JSONSchema{Type: JSONSchemaTypes{"number"}}
+	},
+}
+
+// GenerateJSONSchema generates the JSON Schema of values that decode into the type T with [json.Unmarshal].
+// See [GenerateJSONSchemaFromType] for details.
+func GenerateJSONSchema[T any]() (*JSONSchema, error) {
+	return GenerateJSONSchemaFromType(reflect.TypeFor[T]())
+}
+
+// GenerateJSONSchemaFromType generates the JSON Schema of values that decode into the type with [json.Unmarshal].
+// Struct fields follow the rules of the json tags, including embedded structs and the string option.
+// Named structs are defined in $defs and referenced with $ref, so recursive types are supported.
+// Properties aren't required because missing fields keep their zero values when decoding,
+// and pointers, slices and maps also accept null.
+//
+// Types of this package are described by the values that their UnmarshalJSON methods accept,
+// e.g. [Duration] and [Slug] strings are matched with patterns, [Time] is a date-time string,
+// [AllOrListString] is either "*" or an array of strings and [RegexpMatcher] is a string of the regex format.
+// Other types with UnmarshalJSON methods accept any value and types with UnmarshalText methods accept strings.
+func GenerateJSONSchemaFromType(typ reflect.Type) (*JSONSchema, error) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	generator := &jsonSchemaGenerator{
+		refs: map[reflect.Type]string{typ: "#"},
+		defs: map[string]*JSONSchema{},
+	}
+
+	var (
+		schema *JSONSchema
+		err    error
+	)
+
+	if typ.Kind() == reflect.Struct && knownJSONSchemaTypes[typ] == nil && !isJSONUnmarshalerType(typ) {
+		schema, err = generator.generateStruct(typ)
+	} else {
+		schema, err = generator.generate(typ)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	schema.Schema = JSONSchemaDraft202012
+
+	if len(generator.defs) > 0 {
+		schema.Defs = generator.defs
+	}
+
+	return schema, nil
+}
+
+type jsonSchemaGenerator struct {
+	// The references of generated named structs.
+	refs map[reflect.Type]string
+	defs map[string]*JSONSchema
+}
+
+func (g *jsonSchemaGenerator) generate(typ reflect.Type) (*JSONSchema, error) { //nolint:cyclop
+	if schemaFunc, ok := knownJSONSchemaTypes[typ]; ok {
+		return schemaFunc(), nil
+	}
+
+	if typ.Kind() == reflect.Pointer {
+		schema, err := g.generate(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return nullableJSONSchema(schema), nil
+	}
+
+	if isJSONUnmarshalerType(typ) {
+		return &JSONSchema{}, nil
+	}
+
+	if reflect.PointerTo(typ).Implements(textUnmarshalerType) {
+		return &JSONSchema{Type: JSONSchemaTypes{"string"}}, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: JSONSchemaTypes{"boolean"}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return integerJSONSchema(typ), nil
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: JSONSchemaTypes{"number"}}, nil
+	case reflect.String:
+		return &JSONSchema{Type: JSONSchemaTypes{"string"}}, nil
+	case reflect.Interface:
+		return &JSONSchema{}, nil
+	case reflect.Slice, reflect.Array:
+		return g.generateArray(typ)
+	case reflect.Map:
+		return g.generateMap(typ)
+	case reflect.Struct:
+		return g.generateStructRef(typ)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedJSONSchemaType, typ)
+	}
+}
+
+func (g *jsonSchemaGenerator) generateArray(typ reflect.Type) (*JSONSchema, error) {
+	// Byte slices are encoded as base64 strings.
+	if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 &&
+		!isJSONUnmarshalerType(typ.Elem()) && !reflect.PointerTo(typ.Elem()).Implements(textUnmarshalerType) {
+		return &JSONSchema{Type: JSONSchemaTypes{"string", "null"}}, nil
+	}
+
+	items, err := g.generate(typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	if typ.Kind() == reflect.Array {
+		return &JSONSchema{
+			Type:     JSONSchemaTypes{"array"},
+			Items:    items,
+			MinItems: new(typ.Len()),
+			MaxItems: new(typ.Len()),
+		}, nil
+	}
+
+	return &JSONSchema{Type: JSONSchemaTypes{"array", "null"}, Items: items}, nil
+}
+
+func (g *jsonSchemaGenerator) generateMap(typ reflect.Type) (*JSONSchema, error) {
+	switch typ.Key().Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+	default:
+		if !reflect.PointerTo(typ.Key()).Implements(textUnmarshalerType) {
+			return nil, fmt.Errorf("%w: %s", errUnsupportedJSONSchemaType, typ)
+		}
+	}
+
+	values, err := g.generate(typ.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONSchema{Type: JSONSchemaTypes{"object", "null"}, AdditionalProperties: values}, nil
+}
+
+// generateStructRef returns the reference to the schema of a named struct in $defs.
+// Anonymous structs are generated inline.
+func (g *jsonSchemaGenerator) generateStructRef(typ reflect.Type) (*JSONSchema, error) {
+	if ref, ok := g.refs[typ]; ok {
+		return &JSONSchema{Ref: ref}, nil
+	}
+
+	if typ.Name() == "" {
+		return g.generateStruct(typ)
+	}
+
+	name := g.definitionName(typ)
+	ref := "#/$defs/" + name
+
+	// Register the reference before generating properties, so recursive fields refer to it.
+	g.refs[typ] = ref
+	g.defs[name] = nil
+
+	schema, err := g.generateStruct(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	g.defs[name] = schema
+
+	return &JSONSchema{Ref: ref}, nil
+}
+
+func (g *jsonSchemaGenerator) generateStruct(typ reflect.Type) (*JSONSchema, error) {
+	schema := &JSONSchema{
+		Type:       JSONSchemaTypes{"object"},
+		Properties: map[string]*JSONSchema{},
+	}
+
+	err := g.addStructFields(schema, typ, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// addStructFields adds properties of the struct fields to the schema.
+// Fields of the outer struct take precedence over fields of embedded structs.
+func (g *jsonSchemaGenerator) addStructFields(
+	schema *JSONSchema,
+	typ reflect.Type,
+	visited map[reflect.Type]bool,
+) error {
+	visited[typ] = true
+
+	var embedded []reflect.Type
+
+	for field := range typ.Fields() {
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+
+			if fieldType.Kind() == reflect.Struct && knownJSONSchemaTypes[fieldType] == nil &&
+				!isJSONUnmarshalerType(fieldType) {
+				embedded = append(embedded, fieldType)
+
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if _, ok := schema.Properties[name]; ok {
+			continue
+		}
+
+		fieldSchema, err := g.generateField(field.Type, options)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", typ.Name(), field.Name, err)
+		}
+
+		schema.Properties[name] = fieldSchema
+	}
+
+	for _, embeddedType := range embedded {
+		if visited[embeddedType] {
+			continue
+		}
+
+		err := g.addStructFields(schema, embeddedType, visited)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *jsonSchemaGenerator) generateField(typ reflect.Type, options string) (*JSONSchema, error) {
+	// The string option encodes booleans, numbers and strings as JSON strings.
+	for option := range strings.SplitSeq(options, ",") {
+		if option != "string" {
+			continue
+		}
+
+		elemType := typ
+		if elemType.Kind() == reflect.Pointer {
+			elemType = elemType.Elem()
+		}
+
+		switch elemType.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64, reflect.String:
+			schema := &JSONSchema{Type: JSONSchemaTypes{"string"}}
+			if elemType != typ {
+				return nullableJSONSchema(schema), nil
+			}
+
+			return schema, nil
+		default:
+		}
+	}
+
+	return g.generate(typ)
+}
+
+// definitionName returns a unique name of the named type in $defs.
+func (g *jsonSchemaGenerator) definitionName(typ reflect.Type) string {
+	// Names of generic types include type arguments, e.g. Foo[pkg.Bar].
+	base := strings.Trim(strings.Map(func(r rune) rune {
+		if IsMetaCharacter(r) {
+			return r
+		}
+
+		return '_'
+	}, typ.Name()), "_")
+
+	name := base
+
+	for i := 2; ; i++ {
+		if _, ok := g.defs[name]; !ok {
+			return name
+		}
+
+		name = base + strconv.Itoa(i)
+	}
+}
+
+func allOrListStringJSONSchema() *JSONSchema {
+	return &JSONSchema{
+		OneOf: []*JSONSchema{
+			{Type: JSONSchemaTypes{"string"}, Enum: []any{wildcardSymbol}},
+			// The empty list is encoded as null.
+			{Type: JSONSchemaTypes{"array", "null"}, Items: &JSONSchema{Type: JSONSchemaTypes{"string"}}},
+		},
+	}
+}
+
+// integerJSONSchema returns the schema of an integer type with its range.
+func integerJSONSchema(typ reflect.Type) *JSONSchema {
+	schema := &JSONSchema{Type: JSONSchemaTypes{"integer"}}
+
+	switch typ.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		bits := typ.Bits()
+		schema.Minimum = new(-math.Pow(2, float64(bits-1)))
+		schema.Maximum = new(math.Pow(2, float64(bits-1)) - 1)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		schema.Minimum = new(0.0)
+		schema.Maximum = new(math.Pow(2, float64(typ.Bits())) - 1)
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		schema.Minimum = new(0.0)
+	default:
+	}
+
+	return schema
+}
+
+// nullableJSONSchema allows null in addition to values of the schema.
+func nullableJSONSchema(schema *JSONSchema) *JSONSchema {
+	switch {
+	case schema.Ref == "" && len(schema.Type) == 0 && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0:
+		// The schema accepts any value.
+		return schema
+	case schema.Ref == "" && len(schema.Type) > 0 && len(schema.Enum) == 0:
+		if !slices.Contains(schema.Type, "null") {
+			schema.Type = append(schema.Type, "null")
+		}
+
+		return schema
+	default:
+		return &JSONSchema{AnyOf: []*JSONSchema{{Type: JSONSchemaTypes{"null"}}, schema}}
+	}
+}
+
+func isJSONUnmarshalerType(typ reflect.Type) bool {
+	return typ.Implements(jsonUnmarshalerType) || reflect.PointerTo(typ).Implements(jsonUnmarshalerType)
+}
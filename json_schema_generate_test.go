@@ -0,0 +1,212 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type schemaTestMetadata struct {
+	Owner  string            `json:"owner"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type schemaTestNode struct {
+	Name     string            `json:"name"`
+	Children []*schemaTestNode `json:"children"`
+}
+
+type schemaTestConfig struct {
+	schemaTestMetadata
+
+	ID       Slug                    `json:"id"`
+	Timeout  Duration                `json:"timeout"`
+	StartsAt Time                    `json:"startsAt"`
+	Origins  AllOrListString         `json:"origins"`
+	Hosts    AllOrListWildcardString `json:"hosts"`
+	Path     *RegexpMatcher          `json:"path,omitempty"`
+	Port     uint16                  `json:"port,string"`
+	Ratio    float64                 `json:"ratio"`
+	Enabled  bool                    `json:"enabled"`
+	Tree     schemaTestNode          `json:"tree"`
+	Next     *schemaTestConfig       `json:"next,omitempty"`
+	Extra    any                     `json:"extra"`
+	Ignored  string                  `json:"-"`
+	internal string
+}
+
+func TestGenerateJSONSchema(t *testing.T) {
+	schema, err := GenerateJSONSchema[schemaTestConfig]()
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	rawBytes, err := json.Marshal(schema.Properties)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"enabled":{"type":"boolean"},"extra":{},` +
+		`"hosts":{"oneOf":[{"type":"string","enum":["*"]},{"type":["array","null"],"items":{"type":"string"}}]},` +
+		`"id":{"type":"string","pattern":"^[a-zA-Z0-9_-]*$"},` +
+		`"labels":{"type":["object","null"],"additionalProperties":{"type":"string"}},` +
+		`"next":{"anyOf":[{"type":"null"},{"$ref":"#"}]},` +
+		`"origins":{"oneOf":[{"type":"string","enum":["*"]},{"type":["array","null"],"items":{"type":"string"}}]},` +
+		`"owner":{"type":"string"},` +
+		`"path":{"type":["string","null"],"format":"regex"},` +
+		`"port":{"type":"string"},` +
+		`"ratio":{"type":"number"},` +
+		`"startsAt":{"type":["string","null"],"format":"date-time"},` +
+		`"timeout":{"type":["string","null"],"pattern":"` + durationJSONSchemaPattern + `","minLength":1},` +
+		`"tree":{"$ref":"#/$defs/schemaTestNode"}}`
+
+	if string(rawBytes) != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, rawBytes)
+	}
+
+	if schema.Schema != JSONSchemaDraft202012 || len(schema.Defs) != 1 ||
+		schema.Defs["schemaTestNode"].Properties["children"].Items.AnyOf[1].Ref != "#/$defs/schemaTestNode" {
+		t.Fatalf("unexpected schema: %+v", schema)
+	}
+
+	t.Run("marshaled_values", func(t *testing.T) {
+		value := schemaTestConfig{
+			schemaTestMetadata: schemaTestMetadata{Owner: "core"},
+			ID:                 "my-app_1",
+			Timeout:            Duration(26*time.Hour + 1500*time.Millisecond),
+			StartsAt:           Time(time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)),
+			Origins:            NewAll(),
+			Hosts:              NewAllOrListWildcardStringFromStrings([]string{"*.example.com"}),
+			Path:               MustRegexpMatcher("^/api/(v1|v2)$"),
+			Port:               8080,
+			Tree:               schemaTestNode{Name: "root", Children: []*schemaTestNode{{Name: "leaf"}}},
+			Next:               &schemaTestConfig{Timeout: Duration(0), Extra: []int{1}},
+		}
+
+		document, err := normalizeJSONSchemaValue(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if errs := schema.Validate(document); len(errs) != 0 {
+			t.Fatalf("expected no errors, got: %+v", errs)
+		}
+	})
+
+	t.Run("null_values", func(t *testing.T) {
+		var document any
+
+		err := json.Unmarshal([]byte(`{"timeout": null, "startsAt": null}`), &document)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if errs := schema.Validate(document); len(errs) != 0 {
+			t.Fatalf("expected no errors, got: %+v", errs)
+		}
+
+		var value schemaTestConfig
+
+		err = json.Unmarshal([]byte(`{"timeout": null, "startsAt": null}`), &value)
+		if err != nil {
+			t.Fatalf("expected null values to be decoded, got: %s", err)
+		}
+	})
+
+	t.Run("invalid_values", func(t *testing.T) {
+		var document any
+
+		err := json.Unmarshal([]byte(`{"id": "my app", "timeout": "1s1h", "path": "a(b", "origins": "all",
+			"next": {"timeout": ""}, "port": 8080, "tree": {"children": [{"name": 1}]}}`), &document)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Errors of nullable schemas are reported at the anyOf keyword.
+		expected := []string{"/id", "/next", "/origins", "/path", "/port", "/timeout", "/tree/children/0"}
+
+		var pointers []string
+
+		for _, validationErr := range schema.Validate(document) {
+			pointers = append(pointers, validationErr.Pointer)
+		}
+
+		if !reflect.DeepEqual(pointers, expected) {
+			t.Fatalf("expected errors at %v, got: %v", expected, pointers)
+		}
+	})
+}
+
+func TestGenerateJSONSchema_Types(t *testing.T) {
+	t.Run("known_root", func(t *testing.T) {
+		schema, err := GenerateJSONSchema[*Duration]()
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if schema.Pattern != durationJSONSchemaPattern || len(schema.Defs) != 0 {
+			t.Fatalf("unexpected schema: %+v", schema)
+		}
+	})
+
+	t.Run("collections", func(t *testing.T) {
+		schema, err := GenerateJSONSchema[struct {
+			Bytes  []byte           `json:"bytes"`
+			Pair   [2]int8          `json:"pair"`
+			Counts map[int]uint     `json:"counts"`
+			Raw    json.RawMessage  `json:"raw"`
+			Times  []time.Time      `json:"times"`
+			Sets   map[string][]int `json:"sets"`
+		}]()
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		rawBytes, err := json.Marshal(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{` +
+			`"bytes":{"type":["string","null"]},` +
+			`"counts":{"type":["object","null"],"additionalProperties":{"type":"integer","minimum":0}},` +
+			`"pair":{"type":"array","items":{"type":"integer","minimum":-128,"maximum":127},"minItems":2,"maxItems":2},` +
+			`"raw":{},` +
+			`"sets":{"type":["object","null"],"additionalProperties":{"type":["array","null"],"items":{"type":"integer"}}},` +
+			`"times":{"type":["array","null"],"items":{"type":["string","null"],"format":"date-time"}}}}`
+
+		if string(rawBytes) != expected {
+			t.Fatalf("expected:\n%s\ngot:\n%s", expected, rawBytes)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := GenerateJSONSchema[struct {
+			Callback func() `json:"callback"`
+		}]()
+		if !errors.Is(err, errUnsupportedJSONSchemaType) {
+			t.Fatalf("expected errUnsupportedJSONSchemaType, got: %v", err)
+		}
+
+		_, err = GenerateJSONSchema[map[[2]int]string]()
+		if !errors.Is(err, errUnsupportedJSONSchemaType) {
+			t.Fatalf("expected errUnsupportedJSONSchemaType, got: %v", err)
+		}
+	})
+}
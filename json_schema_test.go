@@ -0,0 +1,303 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/relychan/goutils/httperror"
+)
+
+const testJSONSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["name", "server"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string", "pattern": "^[a-z]+$", "minLength": 2, "maxLength": 8},
+    "env": {"enum": ["dev", "prod"]},
+    "server": {"$ref": "#/$defs/server"},
+    "tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 2},
+    "homepage": {"type": "string", "format": "uri"},
+    "startedAt": {"type": "string", "format": "date-time"},
+    "timeout": {"type": "string", "format": "duration"},
+    "ratio": {"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 1},
+    "scope": {"oneOf": [{"type": "string", "enum": ["*"]}, {"type": "array", "items": {"type": "string"}}]},
+    "owner": {"anyOf": [{"type": "null"}, {"type": "string"}]},
+    "labels": {"type": "object", "additionalProperties": {"type": "string"}}
+  },
+  "$defs": {
+    "server": {
+      "type": "object",
+      "required": ["port"],
+      "properties": {
+        "host": {"type": ["string", "null"]},
+        "port": {"type": "integer", "minimum": 1, "maximum": 65535}
+      }
+    }
+  }
+}`
+
+func TestJSONSchema_Validate(t *testing.T) {
+	var schema JSONSchema
+
+	err := json.Unmarshal([]byte(testJSONSchema), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name     string
+		Value    string
+		Expected []httperror.ValidationError
+	}{
+		{
+			Name: "valid",
+			Value: `{"name": "app", "env": "dev", "server": {"host": null, "port": 8080}, "tags": ["a"],
+				"homepage": "https://example.com", "startedAt": "2026-01-02T03:04:05.123+07:00",
+				"timeout": "PT1H30M", "ratio": 0.5, "scope": "*", "owner": null, "labels": {"team": "core"}}`,
+		},
+		{
+			Name:  "type",
+			Value: `[]`,
+			Expected: []httperror.ValidationError{
+				{Detail: "expected type object, got array", Code: "type"},
+			},
+		},
+		{
+			Name:  "required_and_additional",
+			Value: `{"extra": true, "server": {}}`,
+			Expected: []httperror.ValidationError{
+				{Detail: `missing required property "name"`, Pointer: "/name", Code: "required"},
+				{Detail: `additional property "extra" is not allowed`, Pointer: "/extra", Code: "additionalProperties"},
+				{Detail: `missing required property "port"`, Pointer: "/server/port", Code: "required"},
+			},
+		},
+		{
+			Name: "strings",
+			Value: `{"name": "A", "env": "test", "server": {"port": 1}, "homepage": "/relative",
+				"startedAt": "2026-01-02", "timeout": "1h"}`,
+			Expected: []httperror.ValidationError{
+				{Detail: `value must be one of ["dev","prod"]`, Pointer: "/env", Code: "enum"},
+				{Detail: "string isn't a valid uri", Pointer: "/homepage", Code: "format"},
+				{Detail: "string length must be at least 2", Pointer: "/name", Code: "minLength"},
+				{Detail: `string doesn't match the pattern "^[a-z]+$"`, Pointer: "/name", Code: "pattern"},
+				{Detail: "string isn't a valid date-time", Pointer: "/startedAt", Code: "format"},
+				{Detail: "string isn't a valid duration", Pointer: "/timeout", Code: "format"},
+			},
+		},
+		{
+			Name:  "numbers_and_arrays",
+			Value: `{"name": "app", "server": {"port": 80.5}, "ratio": 1, "tags": [], "labels": {"a": 1}}`,
+			Expected: []httperror.ValidationError{
+				{Detail: "expected type string, got number", Pointer: "/labels/a", Code: "type"},
+				{Detail: "value must be less than 1", Pointer: "/ratio", Code: "exclusiveMaximum"},
+				{Detail: "expected type integer, got number", Pointer: "/server/port", Code: "type"},
+				{Detail: "array must have at least 1 items", Pointer: "/tags", Code: "minItems"},
+			},
+		},
+		{
+			Name:  "composition",
+			Value: `{"name": "app", "server": {"port": 70000, "host": 1}, "scope": 1, "owner": 2}`,
+			Expected: []httperror.ValidationError{
+				{Detail: "value doesn't match any schema of anyOf", Pointer: "/owner", Code: "anyOf"},
+				{Detail: "value must match exactly one schema of oneOf, matched 0", Pointer: "/scope", Code: "oneOf"},
+				{Detail: "expected type string or null, got number", Pointer: "/server/host", Code: "type"},
+				{Detail: "value must be less than or equal to 65535", Pointer: "/server/port", Code: "maximum"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			var value any
+
+			err := json.Unmarshal([]byte(tc.Value), &value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := schema.Validate(value)
+			if !reflect.DeepEqual(result, tc.Expected) {
+				t.Fatalf("expected %+v, got: %+v", tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_Decode(t *testing.T) {
+	t.Run("error_schema", func(t *testing.T) {
+		schema, err := ReadJSONOrYAMLFile[JSONSchema](context.Background(), "docs/error.schema.json")
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		valid := httperror.NewHTTPError(400, "invalid request")
+
+		if errs := schema.Validate(valid); len(errs) != 0 {
+			t.Fatalf("expected no errors, got: %+v", errs)
+		}
+
+		invalid := &httperror.HTTPError{Type: "not a uri", Status: 99}
+		expected := []httperror.ValidationError{
+			{Detail: `missing required property "title"`, Pointer: "/title", Code: "required"},
+			{Detail: "value must be greater than or equal to 100", Pointer: "/status", Code: "minimum"},
+			{Detail: "string isn't a valid uri", Pointer: "/type", Code: "format"},
+		}
+
+		if errs := schema.Validate(invalid); !reflect.DeepEqual(errs, expected) {
+			t.Fatalf("expected %+v, got: %+v", expected, errs)
+		}
+	})
+
+	t.Run("yaml_and_boolean", func(t *testing.T) {
+		schema, err := ReadJSONOrYAMLFile[JSONSchema](
+			context.Background(),
+			"data:application/yaml,"+url.PathEscape(
+				"type: object\nproperties:\n  name: true\n  id:\n    type: integer\nadditionalProperties: false\n",
+			),
+		)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if !schema.Properties["name"].IsBoolean() || !schema.AdditionalProperties.IsBoolean() {
+			t.Fatalf("expected boolean schemas, got: %+v", schema)
+		}
+
+		rawBytes, err := json.Marshal(schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `{"type":"object","properties":{"id":{"type":"integer"},"name":true},"additionalProperties":false}`
+		if string(rawBytes) != expected {
+			t.Fatalf("expected %s, got: %s", expected, rawBytes)
+		}
+
+		errs := schema.Validate(map[string]any{"id": 1, "name": []int{1}, "other": "x"})
+		if len(errs) != 1 || errs[0].Pointer != "/other" {
+			t.Fatalf("expected the additional property error, got: %+v", errs)
+		}
+	})
+
+	t.Run("invalid_ref", func(t *testing.T) {
+		schema := &JSONSchema{
+			Properties: map[string]*JSONSchema{
+				"a": {Ref: "#/$defs/missing"},
+				"b": {Ref: "other.json#/foo"},
+			},
+		}
+
+		errs := schema.Validate(map[string]any{"a": 1, "b": 2})
+		if len(errs) != 2 || errs[0].Code != "$ref" || errs[1].Code != "$ref" {
+			t.Fatalf("expected reference errors, got: %+v", errs)
+		}
+	})
+}
+
+func TestDownloadFileWithSchema(t *testing.T) {
+	var schema JSONSchema
+
+	err := json.Unmarshal([]byte(testJSONSchema), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name    string
+		URL     string
+		Options []DownloadFileOption
+	}{
+		{
+			Name: "json",
+			URL:  "data:application/json," + url.PathEscape(`{"name": "app", "server": {"port": "${PORT}"}}`),
+			Options: []DownloadFileOption{
+				DownloadFileWithEnvLookup(func(string) (string, bool) { return "0", true }),
+			},
+		},
+		{
+			Name: "yaml",
+			URL:  "data:application/yaml," + url.PathEscape("name: app\nserver:\n  port: 0\n"),
+		},
+		{
+			Name: "toml",
+			URL:  "data:application/toml," + url.PathEscape("name = \"app\"\n[server]\nport = 0\n"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			options := append([]DownloadFileOption{DownloadFileWithSchema(&schema)}, tc.Options...)
+
+			_, err := ReadJSONOrYAMLFile[map[string]any](context.Background(), tc.URL, options...)
+
+			var schemaErr SchemaValidationError
+
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("expected SchemaValidationError, got: %v", err)
+			}
+
+			// The env value is expanded to a string.
+			expectedCode := "minimum"
+			if tc.Name == "json" {
+				expectedCode = "type"
+			}
+
+			errs := schemaErr.ValidationErrors()
+			if schemaErr.FilePath != tc.URL || len(errs) != 1 || errs[0].Pointer != "/server/port" ||
+				errs[0].Code != expectedCode {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+
+	t.Run("merge", func(t *testing.T) {
+		_, err := ReadMergedJSONOrYAMLFiles[map[string]any](
+			context.Background(),
+			[]string{
+				"data:application/yaml," + url.PathEscape("name: app\n"),
+				"data:application/json," + url.PathEscape(`{"server": {"port": 8080}}`),
+			},
+			DownloadFileWithSchema(&schema),
+		)
+		if err != nil {
+			t.Fatalf("expected the merged document to be valid, got: %s", err)
+		}
+	})
+
+	t.Run("error_message", func(t *testing.T) {
+		err := SchemaValidationError{
+			FilePath:      "config.yaml",
+			DocumentIndex: 1,
+			Errors: []httperror.ValidationError{
+				{Detail: "expected type string, got number", Pointer: "/name"},
+				{Detail: "expected type object, got array"},
+			},
+		}
+
+		expected := "document of config.yaml at 1 doesn't match the schema: " +
+			"expected type string, got number (/name); expected type object, got array"
+
+		if err.Error() != expected {
+			t.Fatalf("expected %s, got: %s", expected, err.Error())
+		}
+	})
+}
@@ -27,19 +27,24 @@ var (
 
 // DecodeNullableString tries to convert an unknown value to a string pointer.
 func DecodeNullableString(value any) (*string, error) {
+	return defaultDecoder.DecodeNullableString(value)
+}
+
+// DecodeNullableString tries to convert an unknown value to a string pointer with the configuration of the decoder.
+func (d *Decoder) DecodeNullableString(value any) (*string, error) {
 	if value == nil {
 		return nil, nil
 	}
 
 	switch v := value.(type) {
 	case string:
-		return &v, nil
+		return d.nullableString(v), nil
 	case *string:
-		if v == nil {
-			return nil, nil
+		if v == nil || !d.rewritesStrings() {
+			return v, nil
 		}
 
-		return v, nil
+		return d.nullableString(*v), nil
 	case bool,
 		int,
 		int8,
@@ -72,12 +77,17 @@ func DecodeNullableString(value any) (*string, error) {
 		*complex128:
 		return nil, fmt.Errorf("%w, got: %v", ErrMalformedString, reflect.TypeOf(v))
 	default:
-		return DecodeNullableStringReflection(reflect.ValueOf(value))
+		return d.DecodeNullableStringReflection(reflect.ValueOf(value))
 	}
 }
 
 // DecodeNullableStringReflection decodes a nullable string from reflection value.
 func DecodeNullableStringReflection(value reflect.Value) (*string, error) {
+	return defaultDecoder.DecodeNullableStringReflection(value)
+}
+
+// DecodeNullableStringReflection decodes a nullable string from reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeNullableStringReflection(value reflect.Value) (*string, error) {
 	inferredValue, ok := UnwrapPointerFromReflectValue(value)
 	if !ok {
 		return nil, nil
@@ -85,11 +95,11 @@ func DecodeNullableStringReflection(value reflect.Value) (*string, error) {
 
 	switch inferredValue.Kind() {
 	case reflect.String:
-		return new(inferredValue.String()), nil
+		return d.nullableString(inferredValue.String()), nil
 	case reflect.Interface:
 		str, ok := inferredValue.Interface().(string)
 		if ok {
-			return &str, nil
+			return d.nullableString(str), nil
 		}
 	default:
 	}
@@ -99,7 +109,12 @@ func DecodeNullableStringReflection(value reflect.Value) (*string, error) {
 
 // DecodeStringReflection decodes a string from reflection value.
 func DecodeStringReflection(value reflect.Value) (string, error) {
-	result, err := DecodeNullableStringReflection(value)
+	return defaultDecoder.DecodeStringReflection(value)
+}
+
+// DecodeStringReflection decodes a string from reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeStringReflection(value reflect.Value) (string, error) {
+	result, err := d.DecodeNullableStringReflection(value)
 	if err != nil {
 		return "", err
 	}
@@ -113,19 +128,24 @@ func DecodeStringReflection(value reflect.Value) (string, error) {
 
 // DecodeString tries to convert an unknown value to a string value.
 func DecodeString(value any) (string, error) {
+	return defaultDecoder.DecodeString(value)
+}
+
+// DecodeString tries to convert an unknown value to a string value with the configuration of the decoder.
+func (d *Decoder) DecodeString(value any) (string, error) {
 	if value == nil {
 		return "", ErrStringNull
 	}
 
 	switch v := value.(type) {
 	case string:
-		return v, nil
+		return requireDecodedValue(d.nullableString(v), nil, ErrStringNull)
 	case *string:
 		if v == nil {
 			return "", ErrStringNull
 		}
 
-		return *v, nil
+		return requireDecodedValue(d.nullableString(*v), nil, ErrStringNull)
 	case bool,
 		int,
 		int8,
@@ -156,52 +176,46 @@ func DecodeString(value any) (string, error) {
 	case complex64, complex128, *complex64, *complex128, map[string]any, []any:
 		return "", fmt.Errorf("%w, got: %s", ErrMalformedString, reflect.TypeOf(v))
 	default:
-		return DecodeStringReflection(reflect.ValueOf(value))
+		return d.DecodeStringReflection(reflect.ValueOf(value))
 	}
 }
 
 // DecodeStringSlice decodes a string slice from an unknown value.
-func DecodeStringSlice(value any) ([]string, error) { //nolint:funlen
+func DecodeStringSlice(value any) ([]string, error) {
+	return defaultDecoder.DecodeStringSlice(value)
+}
+
+// DecodeStringSlice decodes a string slice from an unknown value with the configuration of the decoder.
+func (d *Decoder) DecodeStringSlice(value any) ([]string, error) { //nolint:funlen
 	if value == nil {
 		return nil, nil
 	}
 
 	switch vs := value.(type) {
 	case []string:
-		return vs, nil
+		if vs == nil || !d.rewritesStrings() {
+			return vs, nil
+		}
+
+		return decodeElements(len(vs), func(i int) (string, error) {
+			return d.DecodeString(vs[i])
+		})
 	case []*string:
 		if vs == nil {
 			return nil, nil
 		}
 
-		results := make([]string, len(vs))
-
-		for i, v := range vs {
-			if v == nil {
-				return nil, fmt.Errorf("failed to decode element at %d: %w", i, ErrStringNull)
-			}
-
-			results[i] = *v
-		}
-
-		return results, nil
+		return decodeElements(len(vs), func(i int) (string, error) {
+			return d.DecodeString(vs[i])
+		})
 	case []any:
 		if vs == nil {
 			return nil, nil
 		}
 
-		results := make([]string, len(vs))
-
-		for i, v := range vs {
-			s, err := DecodeString(v)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decode string at %d: %w", i, err)
-			}
-
-			results[i] = s
-		}
-
-		return results, nil
+		return decodeElements(len(vs), func(i int) (string, error) {
+			return d.DecodeString(vs[i])
+		})
 	case bool,
 		string,
 		int,
@@ -253,12 +267,17 @@ func DecodeStringSlice(value any) ([]string, error) { //nolint:funlen
 		map[string]any:
 		return nil, fmt.Errorf("%w; got: %s", ErrMalformedStringSlice, reflect.TypeOf(vs))
 	default:
-		return DecodeStringSliceReflection(reflect.ValueOf(value))
+		return d.DecodeStringSliceReflection(reflect.ValueOf(value))
 	}
 }
 
 // DecodeStringSliceReflection decodes a string slice from a reflection value.
 func DecodeStringSliceReflection(reflectValue reflect.Value) ([]string, error) {
+	return defaultDecoder.DecodeStringSliceReflection(reflectValue)
+}
+
+// DecodeStringSliceReflection decodes a string slice from a reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeStringSliceReflection(reflectValue reflect.Value) ([]string, error) {
 	reflectValue, ok := UnwrapPointerFromReflectValue(reflectValue)
 	if !ok {
 		return nil, nil
@@ -269,171 +288,313 @@ func DecodeStringSliceReflection(reflectValue reflect.Value) ([]string, error) {
 		return nil, fmt.Errorf("%w; got: %s", ErrMalformedStringSlice, valueKind)
 	}
 
-	valueLen := reflectValue.Len()
-	results := make([]string, valueLen)
-
-	for i := range valueLen {
-		elem, err := DecodeNullableStringReflection(reflectValue.Index(i))
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode string element at %d: %w", i, err)
-		}
-
-		if elem == nil {
-			return nil, fmt.Errorf("failed to decode element at %d: %w", i, ErrStringNull)
-		}
-
-		results[i] = *elem
-	}
-
-	return results, nil
+	return decodeElements(reflectValue.Len(), func(i int) (string, error) {
+		return d.DecodeStringReflection(reflectValue.Index(i))
+	})
 }
 
 // DecodeNullableBooleanSlice decodes a nullable boolean slice from an unknown value.
 func DecodeNullableBooleanSlice(value any) (*[]bool, error) {
-	return decodeNullableBooleanSlice(value, false)
+	return defaultDecoder.DecodeNullableBooleanSlice(value)
+}
+
+// DecodeNullableBooleanSlice decodes a nullable boolean slice from an unknown value with the configuration of the decoder.
+func (d *Decoder) DecodeNullableBooleanSlice(value any) (*[]bool, error) {
+	return d.decodeNullableBooleanSlice(value, false)
 }
 
 // AsNullableBooleanSlice tries to cast a nullable boolean slice from an unknown value.
 func AsNullableBooleanSlice(value any) (*[]bool, error) {
-	return decodeNullableBooleanSlice(value, true)
+	return defaultDecoder.AsNullableBooleanSlice(value)
+}
+
+// AsNullableBooleanSlice tries to cast a nullable boolean slice from an unknown value with the configuration of the decoder.
+func (d *Decoder) AsNullableBooleanSlice(value any) (*[]bool, error) {
+	return d.decodeNullableBooleanSlice(value, true)
 }
 
 // DecodeBooleanSlice decodes a boolean slice from an unknown value.
 func DecodeBooleanSlice(value any) ([]bool, error) {
-	return decodeBooleanSlice(value, false)
+	return defaultDecoder.DecodeBooleanSlice(value)
+}
+
+// DecodeBooleanSlice decodes a boolean slice from an unknown value with the configuration of the decoder.
+func (d *Decoder) DecodeBooleanSlice(value any) ([]bool, error) {
+	return d.decodeBooleanSlice(value, false)
 }
 
 // AsBooleanSlice tries to cast a boolean slice from an unknown value.
 func AsBooleanSlice(value any) ([]bool, error) {
-	return decodeBooleanSlice(value, true)
+	return defaultDecoder.AsBooleanSlice(value)
+}
+
+// AsBooleanSlice tries to cast a boolean slice from an unknown value with the configuration of the decoder.
+func (d *Decoder) AsBooleanSlice(value any) ([]bool, error) {
+	return d.decodeBooleanSlice(value, true)
 }
 
 // DecodeNumber tries to convert an unknown value to a typed number.
 func DecodeNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	value any,
 ) (T, error) {
-	return decodeNumber[T](value, false, false)
+	return decodeNumber[T](defaultDecoder, value, false, false)
+}
+
+// DecodeNumberWith tries to convert an unknown value to a typed number with the configuration of the decoder.
+func DecodeNumberWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value any,
+) (T, error) {
+	return decodeNumber[T](decoder, value, false, false)
 }
 
 // AsNumber tries to cast an unknown value to a typed number.
 func AsNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	value any,
 ) (T, error) {
-	return decodeNumber[T](value, true, false)
+	return decodeNumber[T](defaultDecoder, value, true, false)
+}
+
+// AsNumberWith tries to cast an unknown value to a typed number with the configuration of the decoder.
+func AsNumberWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value any,
+) (T, error) {
+	return decodeNumber[T](decoder, value, true, false)
 }
 
 // DecodeNullableNumber tries to convert an unknown value to a typed number.
 func DecodeNullableNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	value any,
 ) (*T, error) {
-	return decodeNullableNumber[T](value, false, false)
+	return decodeNullableNumber[T](defaultDecoder, value, false, false)
+}
+
+// DecodeNullableNumberWith tries to convert an unknown value to a typed number with the configuration of the decoder.
+func DecodeNullableNumberWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value any,
+) (*T, error) {
+	return decodeNullableNumber[T](decoder, value, false, false)
 }
 
 // AsNullableNumber tries to cast an unknown value to a typed number.
 func AsNullableNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	value any,
 ) (*T, error) {
-	return decodeNullableNumber[T](value, true, false)
+	return decodeNullableNumber[T](defaultDecoder, value, true, false)
+}
+
+// AsNullableNumberWith tries to cast an unknown value to a typed number with the configuration of the decoder.
+func AsNullableNumberWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value any,
+) (*T, error) {
+	return decodeNullableNumber[T](decoder, value, true, false)
 }
 
 // DecodeNullableNumberReflection decodes a nullable numeric value (int, uint, or float) using reflection.
 func DecodeNullableNumberReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	value reflect.Value,
 ) (*T, error) {
-	return decodeNullableNumberReflection[T](value, false, false)
+	return decodeNullableNumberReflection[T](defaultDecoder, value, false, false)
+}
+
+// DecodeNullableNumberReflectionWith decodes a nullable numeric value (int, uint, or float) using reflection with the configuration of the decoder.
+func DecodeNullableNumberReflectionWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value reflect.Value,
+) (*T, error) {
+	return decodeNullableNumberReflection[T](decoder, value, false, false)
 }
 
 // AsNullableNumberReflection tries to cast a nullable numeric value (int, uint, or float) using reflection.
 func AsNullableNumberReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	value reflect.Value,
 ) (*T, error) {
-	return decodeNullableNumberReflection[T](value, true, false)
+	return decodeNullableNumberReflection[T](defaultDecoder, value, true, false)
+}
+
+// AsNullableNumberReflectionWith tries to cast a nullable numeric value (int, uint, or float) using reflection with the configuration of the decoder.
+func AsNullableNumberReflectionWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value reflect.Value,
+) (*T, error) {
+	return decodeNullableNumberReflection[T](decoder, value, true, false)
 }
 
 // DecodeNumberReflection decodes the number value using reflection.
 func DecodeNumberReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	value reflect.Value,
 ) (T, error) {
-	return decodeNumberReflection[T](value, false, false)
+	return decodeNumberReflection[T](defaultDecoder, value, false, false)
+}
+
+// DecodeNumberReflectionWith decodes the number value using reflection with the configuration of the decoder.
+func DecodeNumberReflectionWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value reflect.Value,
+) (T, error) {
+	return decodeNumberReflection[T](decoder, value, false, false)
 }
 
 // AsNumberReflection tries to cast the number value using reflection.
 func AsNumberReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	value reflect.Value,
 ) (T, error) {
-	return decodeNumberReflection[T](value, true, false)
+	return decodeNumberReflection[T](defaultDecoder, value, true, false)
+}
+
+// AsNumberReflectionWith tries to cast the number value using reflection with the configuration of the decoder.
+func AsNumberReflectionWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value reflect.Value,
+) (T, error) {
+	return decodeNumberReflection[T](decoder, value, true, false)
 }
 
 // DecodeNumberSlice decodes a number slice from an unknown value.
 func DecodeNumberSlice[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	value any,
 ) ([]T, error) {
-	return decodeNumberSlice[T](value, false)
+	return decodeNumberSlice[T](defaultDecoder, value, false)
+}
+
+// DecodeNumberSliceWith decodes a number slice from an unknown value with the configuration of the decoder.
+func DecodeNumberSliceWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value any,
+) ([]T, error) {
+	return decodeNumberSlice[T](decoder, value, false)
 }
 
 // AsNumberSlice tries to cast a number slice from an unknown value.
 func AsNumberSlice[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	value any,
 ) ([]T, error) {
-	return decodeNumberSlice[T](value, true)
+	return decodeNumberSlice[T](defaultDecoder, value, true)
+}
+
+// AsNumberSliceWith tries to cast a number slice from an unknown value with the configuration of the decoder.
+func AsNumberSliceWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value any,
+) ([]T, error) {
+	return decodeNumberSlice[T](decoder, value, true)
 }
 
 // DecodeNumberSliceReflection decodes a number slice from a reflection value.
 func DecodeNumberSliceReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	reflectValue reflect.Value,
 ) ([]T, error) {
-	return decodeNumberSliceReflection[T](reflectValue, false)
+	return decodeNumberSliceReflection[T](defaultDecoder, reflectValue, false)
+}
+
+// DecodeNumberSliceReflectionWith decodes a number slice from a reflection value with the configuration of the decoder.
+func DecodeNumberSliceReflectionWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	reflectValue reflect.Value,
+) ([]T, error) {
+	return decodeNumberSliceReflection[T](decoder, reflectValue, false)
 }
 
 // AsNumberSliceReflection tries to cast a number slice from a reflection value.
 func AsNumberSliceReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
 	reflectValue reflect.Value,
 ) ([]T, error) {
-	return decodeNumberSliceReflection[T](reflectValue, true)
+	return decodeNumberSliceReflection[T](defaultDecoder, reflectValue, true)
+}
+
+// AsNumberSliceReflectionWith tries to cast a number slice from a reflection value with the configuration of the decoder.
+func AsNumberSliceReflectionWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	reflectValue reflect.Value,
+) ([]T, error) {
+	return decodeNumberSliceReflection[T](decoder, reflectValue, true)
 }
 
 // DecodeNullableBoolean tries to convert an unknown value to a bool pointer.
 func DecodeNullableBoolean(value any) (*bool, error) {
-	return decodeNullableBoolean(value, false)
+	return defaultDecoder.DecodeNullableBoolean(value)
+}
+
+// DecodeNullableBoolean tries to convert an unknown value to a bool pointer with the configuration of the decoder.
+func (d *Decoder) DecodeNullableBoolean(value any) (*bool, error) {
+	return d.decodeNullableBoolean(value, false)
 }
 
 // AsNullableBoolean tries to cast an unknown value to a bool pointer.
 func AsNullableBoolean(value any) (*bool, error) {
-	return decodeNullableBoolean(value, true)
+	return defaultDecoder.AsNullableBoolean(value)
+}
+
+// AsNullableBoolean tries to cast an unknown value to a bool pointer with the configuration of the decoder.
+func (d *Decoder) AsNullableBoolean(value any) (*bool, error) {
+	return d.decodeNullableBoolean(value, true)
 }
 
 // DecodeBoolean tries to convert an unknown value to a bool value.
 func DecodeBoolean(value any) (bool, error) {
-	return decodeBoolean(value, false)
+	return defaultDecoder.DecodeBoolean(value)
+}
+
+// DecodeBoolean tries to convert an unknown value to a bool value with the configuration of the decoder.
+func (d *Decoder) DecodeBoolean(value any) (bool, error) {
+	return d.decodeBoolean(value, false)
 }
 
 // AsBoolean tries to cast an unknown value to a bool value.
 func AsBoolean(value any) (bool, error) {
-	return decodeBoolean(value, true)
+	return defaultDecoder.AsBoolean(value)
+}
+
+// AsBoolean tries to cast an unknown value to a bool value with the configuration of the decoder.
+func (d *Decoder) AsBoolean(value any) (bool, error) {
+	return d.decodeBoolean(value, true)
 }
 
 // DecodeNullableBooleanReflection decodes a nullable boolean value from reflection.
 func DecodeNullableBooleanReflection(value reflect.Value) (*bool, error) {
-	return decodeNullableBooleanReflection(value, false)
+	return defaultDecoder.DecodeNullableBooleanReflection(value)
+}
+
+// DecodeNullableBooleanReflection decodes a nullable boolean value from reflection with the configuration of the decoder.
+func (d *Decoder) DecodeNullableBooleanReflection(value reflect.Value) (*bool, error) {
+	return d.decodeNullableBooleanReflection(value, false)
 }
 
 // AsNullableBooleanReflection tries to cast a nullable boolean value from reflection.
 func AsNullableBooleanReflection(value reflect.Value) (*bool, error) {
-	return decodeNullableBooleanReflection(value, true)
+	return defaultDecoder.AsNullableBooleanReflection(value)
+}
+
+// AsNullableBooleanReflection tries to cast a nullable boolean value from reflection with the configuration of the decoder.
+func (d *Decoder) AsNullableBooleanReflection(value reflect.Value) (*bool, error) {
+	return d.decodeNullableBooleanReflection(value, true)
 }
 
 // DecodeBooleanReflection decodes a boolean value from reflection.
 func DecodeBooleanReflection(value reflect.Value) (bool, error) {
-	return decodeBooleanReflection(value, false)
+	return defaultDecoder.DecodeBooleanReflection(value)
+}
+
+// DecodeBooleanReflection decodes a boolean value from reflection with the configuration of the decoder.
+func (d *Decoder) DecodeBooleanReflection(value reflect.Value) (bool, error) {
+	return d.decodeBooleanReflection(value, false)
 }
 
 // AsBooleanReflection tries to cast a boolean value from reflection.
 func AsBooleanReflection(value reflect.Value) (bool, error) {
-	return decodeBooleanReflection(value, true)
+	return defaultDecoder.AsBooleanReflection(value)
 }
 
-func decodeBooleanSlice(value any, strict bool) ([]bool, error) {
-	results, err := decodeNullableBooleanSlice(value, strict)
+// AsBooleanReflection tries to cast a boolean value from reflection with the configuration of the decoder.
+func (d *Decoder) AsBooleanReflection(value reflect.Value) (bool, error) {
+	return d.decodeBooleanReflection(value, true)
+}
+
+func (d *Decoder) decodeBooleanSlice(value any, strict bool) ([]bool, error) {
+	results, err := d.decodeNullableBooleanSlice(value, strict)
 	if err != nil {
 		return nil, err
 	}
@@ -445,7 +606,7 @@ func decodeBooleanSlice(value any, strict bool) ([]bool, error) {
 	return *results, nil
 }
 
-func decodeNullableBooleanSliceReflection(value reflect.Value, strict bool) (*[]bool, error) {
+func (d *Decoder) decodeNullableBooleanSliceReflection(value reflect.Value, strict bool) (*[]bool, error) {
 	reflectValue, ok := UnwrapPointerFromReflectValue(value)
 	if !ok {
 		return nil, nil
@@ -456,26 +617,13 @@ func decodeNullableBooleanSliceReflection(value reflect.Value, strict bool) (*[]
 		return nil, fmt.Errorf("%w; got: %s", ErrMalformedBooleanSlice, valueKind)
 	}
 
-	valueLen := reflectValue.Len()
-	results := make([]bool, valueLen)
-
-	for i := range valueLen {
-		elem, err := decodeNullableBooleanReflection(reflectValue.Index(i), strict)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode boolean element at %d: %w", i, err)
-		}
-
-		if elem == nil {
-			return nil, fmt.Errorf("failed to decode boolean element at %d: %w", i, ErrBooleanNull)
-		}
-
-		results[i] = *elem
-	}
-
-	return &results, nil
+	return decodeBooleanElements(reflectValue.Len(), func(i int) (bool, error) {
+		return d.decodeBooleanReflection(reflectValue.Index(i), strict)
+	})
 }
 
 func decodeNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64]( //nolint:cyclop,funlen,gocyclo,gocognit
+	decoder *Decoder,
 	value any,
 	strict bool,
 	isRecursive bool,
@@ -586,7 +734,7 @@ func decodeNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~
 			return 0, fmt.Errorf("%w; got: %s", ErrMalformedNumber, reflect.TypeOf(value))
 		}
 
-		return parseNumber[T](v)
+		return parseNumber[T](decoder, v)
 	case *string:
 		if strict {
 			return 0, fmt.Errorf("%w; got: %s", ErrMalformedNumber, reflect.TypeOf(value))
@@ -596,7 +744,7 @@ func decodeNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~
 			return 0, ErrNumberNull
 		}
 
-		return parseNumber[T](*v)
+		return parseNumber[T](decoder, *v)
 	case bool,
 		complex64,
 		complex128,
@@ -608,11 +756,12 @@ func decodeNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~
 		[]float64:
 		return 0, fmt.Errorf("%w; got: %s", ErrMalformedNumber, reflect.TypeOf(value))
 	default:
-		return decodeNumberReflection[T](reflect.ValueOf(value), strict, isRecursive)
+		return decodeNumberReflection[T](decoder, reflect.ValueOf(value), strict, isRecursive)
 	}
 }
 
 func decodeNullableNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64]( //nolint:cyclop,funlen,gocyclo,gocognit
+	decoder *Decoder,
 	value any,
 	strict bool,
 	isRecursive bool,
@@ -723,12 +872,7 @@ func decodeNullableNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~u
 			return nil, fmt.Errorf("%w; got: %s", ErrMalformedNumber, reflect.TypeOf(value))
 		}
 
-		result, err := parseNumber[T](v)
-		if err != nil {
-			return nil, err
-		}
-
-		return &result, nil
+		return parseNullableNumber[T](decoder, v)
 	case *string:
 		if strict {
 			return nil, fmt.Errorf("%w; got: %s", ErrMalformedNumber, reflect.TypeOf(value))
@@ -738,12 +882,7 @@ func decodeNullableNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~u
 			return nil, nil
 		}
 
-		result, err := parseNumber[T](*v)
-		if err != nil {
-			return nil, err
-		}
-
-		return &result, nil
+		return parseNullableNumber[T](decoder, *v)
 	case bool,
 		complex64,
 		complex128,
@@ -755,11 +894,12 @@ func decodeNullableNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~u
 		[]float64:
 		return nil, fmt.Errorf("%w; got: %s", ErrMalformedNumber, reflect.TypeOf(value))
 	default:
-		return decodeNullableNumberReflection[T](reflect.ValueOf(value), strict, isRecursive)
+		return decodeNullableNumberReflection[T](decoder, reflect.ValueOf(value), strict, isRecursive)
 	}
 }
 
 func decodeNumberSlice[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64]( //nolint:cyclop,funlen,gocyclo
+	decoder *Decoder,
 	value any,
 	strict bool,
 ) ([]T, error) {
@@ -793,42 +933,33 @@ func decodeNumberSlice[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint
 	case []float64:
 		return ToNumberSlice[float64, T](vs), nil
 	case []*int:
-		return PtrToNumberSlice[int, T](vs)
+		return decodeNumberPtrElements[int, T](vs)
 	case []*int8:
-		return PtrToNumberSlice[int8, T](vs)
+		return decodeNumberPtrElements[int8, T](vs)
 	case []*int16:
-		return PtrToNumberSlice[int16, T](vs)
+		return decodeNumberPtrElements[int16, T](vs)
 	case []*int32:
-		return PtrToNumberSlice[int32, T](vs)
+		return decodeNumberPtrElements[int32, T](vs)
 	case []*int64:
-		return PtrToNumberSlice[int64, T](vs)
+		return decodeNumberPtrElements[int64, T](vs)
 	case []*uint:
-		return PtrToNumberSlice[uint, T](vs)
+		return decodeNumberPtrElements[uint, T](vs)
 	case []*uint8:
-		return PtrToNumberSlice[uint8, T](vs)
+		return decodeNumberPtrElements[uint8, T](vs)
 	case []*uint16:
-		return PtrToNumberSlice[uint16, T](vs)
+		return decodeNumberPtrElements[uint16, T](vs)
 	case []*uint32:
-		return PtrToNumberSlice[uint32, T](vs)
+		return decodeNumberPtrElements[uint32, T](vs)
 	case []*uint64:
-		return PtrToNumberSlice[uint64, T](vs)
+		return decodeNumberPtrElements[uint64, T](vs)
 	case []*float32:
-		return PtrToNumberSlice[float32, T](vs)
+		return decodeNumberPtrElements[float32, T](vs)
 	case []*float64:
-		return PtrToNumberSlice[float64, T](vs)
+		return decodeNumberPtrElements[float64, T](vs)
 	case []any:
-		results := make([]T, len(vs))
-
-		for i, v := range vs {
-			n, err := decodeNumber[T](v, strict, false)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decode number at %d: %w", i, err)
-			}
-
-			results[i] = n
-		}
-
-		return results, nil
+		return decodeElements(len(vs), func(i int) (T, error) {
+			return decodeNumber[T](decoder, vs[i], strict, false)
+		})
 	case []string:
 		if strict {
 			return nil, fmt.Errorf(
@@ -838,18 +969,9 @@ func decodeNumberSlice[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint
 			)
 		}
 
-		results := make([]T, len(vs))
-
-		for i, v := range vs {
-			num, err := parseNumber[T](v)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decode number at %d: %w", i, err)
-			}
-
-			results[i] = num
-		}
-
-		return results, nil
+		return decodeElements(len(vs), func(i int) (T, error) {
+			return parseNumber[T](decoder, vs[i])
+		})
 	case bool,
 		string,
 		int,
@@ -886,11 +1008,12 @@ func decodeNumberSlice[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint
 	case []bool, []complex64, []complex128, map[string]any:
 		return nil, fmt.Errorf("%w; got: %s", ErrMalformedNumberSlice, reflect.TypeOf(vs))
 	default:
-		return decodeNumberSliceReflection[T](reflect.ValueOf(value), strict)
+		return decodeNumberSliceReflection[T](decoder, reflect.ValueOf(value), strict)
 	}
 }
 
 func decodeNumberSliceReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
 	reflectValue reflect.Value,
 	strict bool,
 ) ([]T, error) {
@@ -904,26 +1027,13 @@ func decodeNumberSliceReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~ui
 		return nil, fmt.Errorf("%w; got: %s", ErrMalformedNumberSlice, valueKind)
 	}
 
-	valueLen := reflectValue.Len()
-	results := make([]T, valueLen)
-
-	for i := range valueLen {
-		elem, err := decodeNullableNumberReflection[T](reflectValue.Index(i), strict, false)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode number element at %d: %w", i, err)
-		}
-
-		if elem == nil {
-			return nil, fmt.Errorf("failed to decode number element at %d: %w", i, ErrNumberNull)
-		}
-
-		results[i] = *elem
-	}
-
-	return results, nil
+	return decodeElements(reflectValue.Len(), func(i int) (T, error) {
+		return decodeNumberReflection[T](decoder, reflectValue.Index(i), strict, false)
+	})
 }
 
 func decodeNullableNumberReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
 	value reflect.Value,
 	strict bool,
 	isRecursive bool,
@@ -947,19 +1057,14 @@ func decodeNullableNumberReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 |
 			return nil, fmt.Errorf("%w, got: %s <%s>", ErrMalformedNumber, value.Type(), kind)
 		}
 
-		result, err := parseNumber[T](inferredValue.String())
-		if err != nil {
-			return nil, err
-		}
-
-		return &result, nil
+		return parseNullableNumber[T](decoder, inferredValue.String())
 	case reflect.Interface:
 		// guard against infinite loop.
 		if isRecursive {
 			return nil, fmt.Errorf("%w, got: %s <%s>", ErrMalformedNumber, value.Type(), kind)
 		}
 
-		result, err := decodeNullableNumber[T](inferredValue.Interface(), strict, true)
+		result, err := decodeNullableNumber[T](decoder, inferredValue.Interface(), strict, true)
 		if err != nil {
 			return nil, err
 		}
@@ -971,11 +1076,12 @@ func decodeNullableNumberReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 |
 }
 
 func decodeNumberReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
 	value reflect.Value,
 	strict bool,
 	isRecursive bool,
 ) (T, error) {
-	result, err := decodeNullableNumberReflection[T](value, strict, isRecursive)
+	result, err := decodeNullableNumberReflection[T](decoder, value, strict, isRecursive)
 	if err != nil {
 		return 0, err
 	}
@@ -987,7 +1093,7 @@ func decodeNumberReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint |
 	return *result, nil
 }
 
-func decodeNullableBoolean(value any, strict bool) (*bool, error) {
+func (d *Decoder) decodeNullableBoolean(value any, strict bool) (*bool, error) {
 	if value == nil {
 		return nil, nil
 	}
@@ -1002,12 +1108,7 @@ func decodeNullableBoolean(value any, strict bool) (*bool, error) {
 			return nil, ErrMalformedBoolean
 		}
 
-		result, err := parseBool(v)
-		if err != nil {
-			return nil, err
-		}
-
-		return &result, nil
+		return d.parseNullableBool(v)
 	case *string:
 		if strict {
 			return nil, ErrMalformedBoolean
@@ -1017,18 +1118,13 @@ func decodeNullableBoolean(value any, strict bool) (*bool, error) {
 			return nil, nil
 		}
 
-		result, err := parseBool(*v)
-		if err != nil {
-			return nil, err
-		}
-
-		return &result, nil
+		return d.parseNullableBool(*v)
 	default:
-		return decodeNullableBooleanReflection(reflect.ValueOf(value), strict)
+		return d.decodeNullableBooleanReflection(reflect.ValueOf(value), strict)
 	}
 }
 
-func decodeNullableBooleanReflection(reflectValue reflect.Value, strict bool) (*bool, error) {
+func (d *Decoder) decodeNullableBooleanReflection(reflectValue reflect.Value, strict bool) (*bool, error) {
 	value, ok := UnwrapPointerFromReflectValue(reflectValue)
 	if !ok {
 		return nil, nil
@@ -1041,12 +1137,7 @@ func decodeNullableBooleanReflection(reflectValue reflect.Value, strict bool) (*
 		return new(value.Bool()), nil
 	case reflect.String:
 		if !strict {
-			result, err := parseBool(value.String())
-			if err != nil {
-				return nil, err
-			}
-
-			return &result, nil
+			return d.parseNullableBool(value.String())
 		}
 	case reflect.Interface:
 		if value.Equal(trueValue) {
@@ -1060,12 +1151,7 @@ func decodeNullableBooleanReflection(reflectValue reflect.Value, strict bool) (*
 		if !strict {
 			strValue, ok := value.Interface().(string)
 			if ok {
-				result, err := parseBool(strValue)
-				if err != nil {
-					return nil, err
-				}
-
-				return &result, nil
+				return d.parseNullableBool(strValue)
 			}
 		}
 	default:
@@ -1074,8 +1160,8 @@ func decodeNullableBooleanReflection(reflectValue reflect.Value, strict bool) (*
 	return nil, fmt.Errorf("%w; got: %v", ErrMalformedBoolean, kind)
 }
 
-func decodeBooleanReflection(value reflect.Value, strict bool) (bool, error) {
-	result, err := decodeNullableBooleanReflection(value, strict)
+func (d *Decoder) decodeBooleanReflection(value reflect.Value, strict bool) (bool, error) {
+	result, err := d.decodeNullableBooleanReflection(value, strict)
 	if err != nil {
 		return false, err
 	}
@@ -1087,7 +1173,7 @@ func decodeBooleanReflection(value reflect.Value, strict bool) (bool, error) {
 	return *result, nil
 }
 
-func decodeNullableBooleanSlice(value any, strict bool) (*[]bool, error) { //nolint:cyclop,funlen
+func (d *Decoder) decodeNullableBooleanSlice(value any, strict bool) (*[]bool, error) { //nolint:cyclop,funlen
 	if value == nil {
 		return nil, nil
 	}
@@ -1098,34 +1184,17 @@ func decodeNullableBooleanSlice(value any, strict bool) (*[]bool, error) { //nol
 	case *[]bool:
 		return vs, nil
 	case []*bool:
-		results := make([]bool, len(vs))
-
-		for i, v := range vs {
-			if v == nil {
-				return nil, fmt.Errorf(
-					"failed to decode boolean element at %d: %w",
-					i,
-					ErrBooleanNull,
-				)
+		return decodeBooleanElements(len(vs), func(i int) (bool, error) {
+			if vs[i] == nil {
+				return false, ErrBooleanNull
 			}
 
-			results[i] = *v
-		}
-
-		return &results, nil
+			return *vs[i], nil
+		})
 	case []any:
-		results := make([]bool, len(vs))
-
-		for i, v := range vs {
-			n, err := decodeBoolean(v, strict)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decode boolean element at %d: %w", i, err)
-			}
-
-			results[i] = n
-		}
-
-		return &results, nil
+		return decodeBooleanElements(len(vs), func(i int) (bool, error) {
+			return d.decodeBoolean(vs[i], strict)
+		})
 	case []string:
 		if strict {
 			return nil, fmt.Errorf(
@@ -1135,18 +1204,9 @@ func decodeNullableBooleanSlice(value any, strict bool) (*[]bool, error) { //nol
 			)
 		}
 
-		results := make([]bool, len(vs))
-
-		for i, v := range vs {
-			num, err := parseBool(v)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decode boolean element at %d: %w", i, err)
-			}
-
-			results[i] = num
-		}
-
-		return &results, nil
+		return decodeBooleanElements(len(vs), func(i int) (bool, error) {
+			return d.parseBool(vs[i])
+		})
 	case bool,
 		string,
 		int,
@@ -1197,41 +1257,56 @@ func decodeNullableBooleanSlice(value any, strict bool) (*[]bool, error) { //nol
 		map[string]any:
 		return nil, fmt.Errorf("%w; got: %s", ErrMalformedBooleanSlice, reflect.TypeOf(vs))
 	default:
-		return decodeNullableBooleanSliceReflection(reflect.ValueOf(value), strict)
+		return d.decodeNullableBooleanSliceReflection(reflect.ValueOf(value), strict)
 	}
 }
 
 func parseNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
 	rawValue string,
 ) (T, error) {
+	result, err := parseNullableNumber[T](decoder, rawValue)
+
+	return requireDecodedValue(result, err, ErrNumberNull)
+}
+
+func parseNullableNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	rawValue string,
+) (*T, error) {
+	rawValue, isNull := decoder.normalizeString(rawValue)
+	if isNull {
+		return nil, nil
+	}
+
 	var empty T
 
 	switch any(empty).(type) {
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32:
 		result, err := strconv.ParseInt(rawValue, 10, 64)
 		if err != nil {
-			return 0, ErrMalformedNumber
+			return nil, ErrMalformedNumber
 		}
 
-		return T(result), nil
+		return new(T(result)), nil
 	case uint64:
 		result, err := strconv.ParseUint(rawValue, 10, 64)
 		if err != nil {
-			return 0, ErrMalformedNumber
+			return nil, ErrMalformedNumber
 		}
 
-		return T(result), nil
+		return new(T(result)), nil
 	default:
 		fResult, err := strconv.ParseFloat(rawValue, 64)
 		if err != nil {
-			return 0, ErrMalformedNumber
+			return nil, ErrMalformedNumber
 		}
 
-		return T(fResult), nil
+		return new(T(fResult)), nil
 	}
 }
 
-func decodeBoolean(value any, strict bool) (bool, error) {
+func (d *Decoder) decodeBoolean(value any, strict bool) (bool, error) {
 	if value == nil {
 		return false, ErrBooleanNull
 	}
@@ -1250,7 +1325,7 @@ func decodeBoolean(value any, strict bool) (bool, error) {
 			return false, ErrMalformedBoolean
 		}
 
-		return parseBool(v)
+		return d.parseBool(v)
 	case *string:
 		if strict {
 			return false, ErrMalformedBoolean
@@ -1260,17 +1335,30 @@ func decodeBoolean(value any, strict bool) (bool, error) {
 			return false, ErrBooleanNull
 		}
 
-		return parseBool(*v)
+		return d.parseBool(*v)
 	default:
-		return decodeBooleanReflection(reflect.ValueOf(value), strict)
+		return d.decodeBooleanReflection(reflect.ValueOf(value), strict)
 	}
 }
 
-func parseBool(value string) (bool, error) {
-	result, err := strconv.ParseBool(value)
+// decodeNumberPtrElements converts a slice of number pointers, collecting errors of all null elements.
+func decodeNumberPtrElements[T1, T2 ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	inputs []*T1,
+) ([]T2, error) {
+	return decodeElements(len(inputs), func(i int) (T2, error) {
+		if inputs[i] == nil {
+			return 0, ErrNumberNull
+		}
+
+		return T2(*inputs[i]), nil
+	})
+}
+
+func decodeBooleanElements(length int, decodeElem func(index int) (bool, error)) (*[]bool, error) {
+	results, err := decodeElements(length, decodeElem)
 	if err != nil {
-		return false, ErrMalformedBoolean
+		return nil, err
 	}
 
-	return result, nil
+	return &results, nil
 }
@@ -0,0 +1,379 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// NumberConversionError occurs when a number can't be converted to the target type
+// without overflowing or losing precision.
+type NumberConversionError struct {
+	// The source value.
+	Value any
+	// The target type.
+	Type reflect.Type
+	// Either [ErrNumberOutOfRange] or [ErrNumberPrecisionLoss].
+	Err error
+}
+
+// Error implements the error interface for NumberConversionError.
+func (e NumberConversionError) Error() string {
+	return fmt.Sprintf("cannot convert %v to %s: %s", e.Value, e.Type, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e NumberConversionError) Unwrap() error {
+	return e.Err
+}
+
+// checkedNumber is the result of a checked conversion. Only the field of the kind is set.
+type checkedNumber struct {
+	kind  reflect.Kind
+	int   int64
+	uint  uint64
+	float float64
+}
+
+// DecodeNumberChecked tries to convert an unknown value to a typed number without overflowing or losing precision.
+// Unlike [DecodeNumber], it returns a [NumberConversionError] if the value is out of the range of the type,
+// e.g. 1e20 to int32 or -1 to uint8, or if it has a fraction for integer types, e.g. 1.5 to int.
+// Floats are rounded to the nearest value of float types, but integers must be exactly representable.
+// Strings and [json.Number] values are parsed exactly without going through float64, so big numbers are supported.
+func DecodeNumberChecked[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	value any,
+) (T, error) {
+	return DecodeNumberCheckedWith[T](defaultDecoder, value)
+}
+
+// DecodeNumberCheckedWith tries to convert an unknown value to a typed number without overflowing or losing precision
+// with the configuration of the decoder. See [DecodeNumberChecked] for details.
+func DecodeNumberCheckedWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value any,
+) (T, error) {
+	result, err := DecodeNullableNumberCheckedWith[T](decoder, value)
+
+	return requireDecodedValue(result, err, ErrNumberNull)
+}
+
+// DecodeNullableNumberChecked tries to convert an unknown value to a typed number pointer
+// without overflowing or losing precision. See [DecodeNumberChecked] for details.
+func DecodeNullableNumberChecked[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	value any,
+) (*T, error) {
+	return DecodeNullableNumberCheckedWith[T](defaultDecoder, value)
+}
+
+// DecodeNullableNumberCheckedWith tries to convert an unknown value to a typed number pointer
+// without overflowing or losing precision with the configuration of the decoder. See [DecodeNumberChecked] for details.
+func DecodeNullableNumberCheckedWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value any,
+) (*T, error) {
+	return DecodeNullableNumberCheckedReflectionWith[T](decoder, reflect.ValueOf(value))
+}
+
+// DecodeNumberCheckedReflection decodes a typed number from reflection value without overflowing or losing precision.
+// See [DecodeNumberChecked] for details.
+func DecodeNumberCheckedReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	value reflect.Value,
+) (T, error) {
+	return DecodeNumberCheckedReflectionWith[T](defaultDecoder, value)
+}
+
+// DecodeNumberCheckedReflectionWith decodes a typed number from reflection value without overflowing or losing precision
+// with the configuration of the decoder. See [DecodeNumberChecked] for details.
+func DecodeNumberCheckedReflectionWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value reflect.Value,
+) (T, error) {
+	result, err := DecodeNullableNumberCheckedReflectionWith[T](decoder, value)
+
+	return requireDecodedValue(result, err, ErrNumberNull)
+}
+
+// DecodeNullableNumberCheckedReflection decodes a typed number pointer from reflection value
+// without overflowing or losing precision. See [DecodeNumberChecked] for details.
+func DecodeNullableNumberCheckedReflection[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	value reflect.Value,
+) (*T, error) {
+	return DecodeNullableNumberCheckedReflectionWith[T](defaultDecoder, value)
+}
+
+// DecodeNullableNumberCheckedReflectionWith decodes a typed number pointer from reflection value
+// without overflowing or losing precision with the configuration of the decoder. See [DecodeNumberChecked] for details.
+func DecodeNullableNumberCheckedReflectionWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value reflect.Value,
+) (*T, error) {
+	result, err := decoder.decodeCheckedNumberReflection(value, reflect.TypeFor[T]())
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	switch result.kind {
+	case reflect.Int64:
+		return new(T(result.int)), nil
+	case reflect.Uint64:
+		return new(T(result.uint)), nil
+	default:
+		return new(T(result.float)), nil
+	}
+}
+
+// DecodeNumberSliceChecked decodes a number slice from an unknown value without overflowing or losing precision.
+// See [DecodeNumberChecked] for details.
+func DecodeNumberSliceChecked[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	value any,
+) ([]T, error) {
+	return DecodeNumberSliceCheckedWith[T](defaultDecoder, value)
+}
+
+// DecodeNumberSliceCheckedWith decodes a number slice from an unknown value without overflowing or losing precision
+// with the configuration of the decoder. See [DecodeNumberChecked] for details.
+func DecodeNumberSliceCheckedWith[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	decoder *Decoder,
+	value any,
+) ([]T, error) {
+	return decodeSliceReflection(
+		reflect.ValueOf(value),
+		func(elem reflect.Value) (*T, error) {
+			return DecodeNullableNumberCheckedReflectionWith[T](decoder, elem)
+		},
+		ErrMalformedNumberSlice,
+		ErrNumberNull,
+	)
+}
+
+// decodeCheckedNumberReflection converts the value to a number of the target type.
+// The result is nil if the value is null.
+func (d *Decoder) decodeCheckedNumberReflection(value reflect.Value, targetType reflect.Type) (*checkedNumber, error) {
+	inferredValue, ok := UnwrapPointerFromReflectValue(value)
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		result checkedNumber
+		err    error
+	)
+
+	switch inferredValue.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result, err = convertCheckedInt(inferredValue.Int(), targetType)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		result, err = convertCheckedUint(inferredValue.Uint(), targetType)
+	case reflect.Float32, reflect.Float64:
+		result, err = convertCheckedFloat(inferredValue.Float(), targetType)
+	case reflect.String:
+		str, isNull := d.normalizeString(inferredValue.String())
+		if isNull {
+			return nil, nil
+		}
+
+		result, err = convertCheckedString(str, targetType)
+	case reflect.Interface:
+		return d.decodeCheckedNumberReflection(reflect.ValueOf(inferredValue.Interface()), targetType)
+	default:
+		return nil, fmt.Errorf("%w; got: %s", ErrMalformedNumber, inferredValue.Type())
+	}
+
+	if err == nil {
+		return &result, nil
+	}
+
+	if errors.Is(err, ErrNumberOutOfRange) || errors.Is(err, ErrNumberPrecisionLoss) {
+		return nil, NumberConversionError{Value: inferredValue.Interface(), Type: targetType, Err: err}
+	}
+
+	return nil, err
+}
+
+func convertCheckedInt(value int64, targetType reflect.Type) (checkedNumber, error) {
+	bits := targetType.Bits()
+
+	switch {
+	case isSignedIntKind(targetType.Kind()):
+		if bits < 64 && (value < -1<<(bits-1) || value > 1<<(bits-1)-1) {
+			return checkedNumber{}, ErrNumberOutOfRange
+		}
+
+		return checkedNumber{kind: reflect.Int64, int: value}, nil
+	case isUnsignedIntKind(targetType.Kind()):
+		if value < 0 {
+			return checkedNumber{}, ErrNumberOutOfRange
+		}
+
+		return convertCheckedUint(uint64(value), targetType)
+	default:
+		return convertCheckedBigFloat(new(big.Float).SetInt64(value), targetType, true)
+	}
+}
+
+func convertCheckedUint(value uint64, targetType reflect.Type) (checkedNumber, error) {
+	bits := targetType.Bits()
+
+	switch {
+	case isSignedIntKind(targetType.Kind()):
+		if value > 1<<(bits-1)-1 {
+			return checkedNumber{}, ErrNumberOutOfRange
+		}
+
+		return checkedNumber{kind: reflect.Int64, int: int64(value)}, nil
+	case isUnsignedIntKind(targetType.Kind()):
+		if bits < 64 && value > 1<<bits-1 {
+			return checkedNumber{}, ErrNumberOutOfRange
+		}
+
+		return checkedNumber{kind: reflect.Uint64, uint: value}, nil
+	default:
+		return convertCheckedBigFloat(new(big.Float).SetUint64(value), targetType, true)
+	}
+}
+
+func convertCheckedFloat(value float64, targetType reflect.Type) (checkedNumber, error) {
+	if !isSignedIntKind(targetType.Kind()) && !isUnsignedIntKind(targetType.Kind()) {
+		if targetType.Bits() == 64 || math.IsNaN(value) || math.IsInf(value, 0) {
+			return checkedNumber{kind: reflect.Float64, float: value}, nil
+		}
+
+		return convertCheckedBigFloat(new(big.Float).SetFloat64(value), targetType, value == math.Trunc(value))
+	}
+
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return checkedNumber{}, ErrNumberOutOfRange
+	}
+
+	return convertCheckedBigFloat(new(big.Float).SetFloat64(value), targetType, true)
+}
+
+func convertCheckedString(value string, targetType reflect.Type) (checkedNumber, error) {
+	if result, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return convertCheckedInt(result, targetType)
+	}
+
+	if result, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return convertCheckedUint(result, targetType)
+	}
+
+	if !isSignedIntKind(targetType.Kind()) && !isUnsignedIntKind(targetType.Kind()) {
+		result, err := strconv.ParseFloat(value, targetType.Bits())
+		if err == nil {
+			return checkedNumber{kind: reflect.Float64, float: result}, nil
+		}
+
+		if errors.Is(err, strconv.ErrRange) {
+			return checkedNumber{}, ErrNumberOutOfRange
+		}
+
+		return checkedNumber{}, ErrMalformedNumber
+	}
+
+	// Each decimal digit needs less than 4 bits, so the precision is enough to keep every digit of the string.
+	result, _, err := big.ParseFloat(value, 10, uint(64+4*len(value)), big.ToNearestEven)
+	if err != nil {
+		return checkedNumber{}, ErrMalformedNumber
+	}
+
+	return convertCheckedBigFloat(result, targetType, true)
+}
+
+// convertCheckedBigFloat converts the exact value to the target type.
+// If exactInteger is true, integers that can't be represented exactly by float types are precision errors.
+func convertCheckedBigFloat(value *big.Float, targetType reflect.Type, exactInteger bool) (checkedNumber, error) {
+	kind := targetType.Kind()
+	bits := targetType.Bits()
+
+	if value.IsInf() {
+		return checkedNumber{}, ErrNumberOutOfRange
+	}
+
+	if isSignedIntKind(kind) || isUnsignedIntKind(kind) {
+		if !value.IsInt() {
+			return checkedNumber{}, ErrNumberPrecisionLoss
+		}
+
+		if isSignedIntKind(kind) {
+			minValue := new(big.Float).SetInt64(-1 << (bits - 1))
+			maxValue := new(big.Float).SetUint64(1<<(bits-1) - 1)
+
+			if value.Cmp(minValue) < 0 || value.Cmp(maxValue) > 0 {
+				return checkedNumber{}, ErrNumberOutOfRange
+			}
+
+			result, _ := value.Int64()
+
+			return checkedNumber{kind: reflect.Int64, int: result}, nil
+		}
+
+		maxValue := new(big.Float).SetUint64(math.MaxUint64 >> (64 - bits))
+
+		if value.Sign() < 0 || value.Cmp(maxValue) > 0 {
+			return checkedNumber{}, ErrNumberOutOfRange
+		}
+
+		result, _ := value.Uint64()
+
+		return checkedNumber{kind: reflect.Uint64, uint: result}, nil
+	}
+
+	var (
+		result   float64
+		accuracy big.Accuracy
+	)
+
+	if bits == 32 {
+		var result32 float32
+
+		result32, accuracy = value.Float32()
+		result = float64(result32)
+	} else {
+		result, accuracy = value.Float64()
+	}
+
+	if math.IsInf(result, 0) {
+		return checkedNumber{}, ErrNumberOutOfRange
+	}
+
+	if exactInteger && accuracy != big.Exact {
+		return checkedNumber{}, ErrNumberPrecisionLoss
+	}
+
+	return checkedNumber{kind: reflect.Float64, float: result}, nil
+}
+
+func isSignedIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUnsignedIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
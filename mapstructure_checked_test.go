@@ -0,0 +1,156 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeNumberChecked(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		assertCheckedNumber(t, int32(-2147483648), float64(-2147483648))
+		assertCheckedNumber(t, uint8(255), "255")
+		assertCheckedNumber(t, int(2), 2.0)
+		assertCheckedNumber(t, int64(100), "1e2")
+		assertCheckedNumber(t, uint64(math.MaxUint64), json.Number("18446744073709551615"))
+		assertCheckedNumber(t, uint64(math.MaxUint64), "1.8446744073709551615e19")
+		assertCheckedNumber(t, int64(math.MinInt64), "-9223372036854775808")
+		assertCheckedNumber(t, int16(7), new(uint64(7)))
+		assertCheckedNumber(t, float32(0.1), 0.1)
+		assertCheckedNumber(t, float32(16777216), int64(16777216))
+		assertCheckedNumber(t, float64(0.1), "0.1")
+		assertCheckedNumber(t, float64(1e300), "1e300")
+		assertCheckedNumber(t, float64(9007199254740992), uint64(9007199254740992))
+		assertCheckedNumber(t, int8(1), []any{json.Number("1")}[0])
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			Name     string
+			Decode   func() error
+			Expected error
+		}{
+			{"float_to_int32", decodeCheckedNumberFunc[int32](1e20), ErrNumberOutOfRange},
+			{"negative_to_uint8", decodeCheckedNumberFunc[uint8](-1), ErrNumberOutOfRange},
+			{"fraction_to_int", decodeCheckedNumberFunc[int](1.5), ErrNumberPrecisionLoss},
+			{"int64_to_int8", decodeCheckedNumberFunc[int8](int64(128)), ErrNumberOutOfRange},
+			{"uint64_to_int64", decodeCheckedNumberFunc[int64](uint64(math.MaxInt64) + 1), ErrNumberOutOfRange},
+			{"big_string", decodeCheckedNumberFunc[uint64]("18446744073709551616"), ErrNumberOutOfRange},
+			{"big_fraction", decodeCheckedNumberFunc[int64]("9007199254740993.5"), ErrNumberPrecisionLoss},
+			{"json_number", decodeCheckedNumberFunc[int32](json.Number("2147483648")), ErrNumberOutOfRange},
+			{"infinity", decodeCheckedNumberFunc[int](math.Inf(1)), ErrNumberOutOfRange},
+			{"nan", decodeCheckedNumberFunc[uint](math.NaN()), ErrNumberOutOfRange},
+			{"float32_overflow", decodeCheckedNumberFunc[float32](1e40), ErrNumberOutOfRange},
+			{"float32_string_overflow", decodeCheckedNumberFunc[float32]("1e40"), ErrNumberOutOfRange},
+			{"float64_integer", decodeCheckedNumberFunc[float64](int64(9007199254740993)), ErrNumberPrecisionLoss},
+			{"float32_integer", decodeCheckedNumberFunc[float32](float64(16777217)), ErrNumberPrecisionLoss},
+			{"malformed_string", decodeCheckedNumberFunc[int]("1a"), ErrMalformedNumber},
+			{"malformed_float_string", decodeCheckedNumberFunc[float64]("x"), ErrMalformedNumber},
+			{"boolean", decodeCheckedNumberFunc[int](true), ErrMalformedNumber},
+			{"null", decodeCheckedNumberFunc[int](nil), ErrNumberNull},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.Name, func(t *testing.T) {
+				err := tc.Decode()
+				if !errors.Is(err, tc.Expected) {
+					t.Fatalf("expected %s, got: %v", tc.Expected, err)
+				}
+			})
+		}
+	})
+
+	t.Run("error_type", func(t *testing.T) {
+		_, err := DecodeNumberChecked[uint8](-1)
+
+		var conversionErr NumberConversionError
+
+		if !errors.As(err, &conversionErr) {
+			t.Fatalf("expected NumberConversionError, got: %v", err)
+		}
+
+		assertEqual(t, reflect.TypeFor[uint8](), conversionErr.Type)
+		assertEqual(t, "cannot convert -1 to uint8: number is out of range", err.Error())
+	})
+
+	t.Run("nullable", func(t *testing.T) {
+		result, err := DecodeNullableNumberChecked[int]((*string)(nil))
+		if err != nil || result != nil {
+			t.Fatalf("expected nil, got: %v, %v", result, err)
+		}
+
+		_, err = DecodeNumberCheckedReflection[int](reflect.ValueOf((*int)(nil)))
+		if !errors.Is(err, ErrNumberNull) {
+			t.Fatalf("expected ErrNumberNull, got: %v", err)
+		}
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		result, err := DecodeNumberSliceChecked[uint16]([]any{1, "2", 3.0})
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		assertDeepEqual(t, []uint16{1, 2, 3}, result)
+
+		_, err = DecodeNumberSliceChecked[uint16]([]int{1, 65536})
+		if !errors.Is(err, ErrNumberOutOfRange) {
+			t.Fatalf("expected ErrNumberOutOfRange, got: %v", err)
+		}
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		_, err := DecodeStruct[struct {
+			Port  uint16 `json:"port"`
+			Count int    `json:"count"`
+		}](map[string]any{"port": 70000, "count": 1.5})
+
+		var decodeErrs DecodeFieldErrors
+
+		if !errors.As(err, &decodeErrs) || len(decodeErrs) != 2 ||
+			!errors.Is(decodeErrs[0], ErrNumberOutOfRange) || !errors.Is(decodeErrs[1], ErrNumberPrecisionLoss) {
+			t.Fatalf("expected range and precision errors, got: %v", err)
+		}
+	})
+}
+
+func assertCheckedNumber[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	t *testing.T,
+	expected T,
+	value any,
+) {
+	t.Helper()
+
+	result, err := DecodeNumberChecked[T](value)
+	if err != nil {
+		t.Fatalf("expected nil error of %v, got: %s", value, err)
+	}
+
+	assertEqual(t, expected, result)
+}
+
+func decodeCheckedNumberFunc[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64](
+	value any,
+) func() error {
+	return func() error {
+		_, err := DecodeNumberChecked[T](value)
+
+		return err
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"slices"
+	"strings"
+)
+
+var (
+	// defaultTruthyValues are the true values accepted by [strconv.ParseBool].
+	defaultTruthyValues = []string{"1", "t", "T", "TRUE", "true", "True"}
+	// defaultFalsyValues are the false values accepted by [strconv.ParseBool].
+	defaultFalsyValues = []string{"0", "f", "F", "FALSE", "false", "False"}
+)
+
+// defaultDecoder is the configuration of the package-level Decode* and As* functions.
+var defaultDecoder = &Decoder{}
+
+// Decoder configures how strings of loosely typed values are interpreted, e.g. inputs of CSV imports,
+// environment variables or legacy APIs that use "yes" and "no" for booleans and empty strings for null.
+// The zero value decodes values in the same way as the package-level Decode* and As* functions.
+//
+// Every Decode* and As* function is available as a method of the decoder. Go methods can't have type parameters,
+// so generic functions have a variant with the With suffix that accepts the decoder, e.g. [DecodeNumberWith].
+//
+// Strings are trimmed before they are decoded. Null values are matched first, so the decoded value is null,
+// e.g. a nil pointer, or a null error of the non-nullable functions. The vocabularies only apply to strings,
+// so they don't affect As* functions that don't accept strings for numbers and booleans.
+type Decoder struct {
+	// Strings that are decoded as true.
+	// Defaults to the values accepted by [strconv.ParseBool]: 1, t, T, TRUE, true and True.
+	TruthyValues []string
+	// Strings that are decoded as false.
+	// Defaults to the values accepted by [strconv.ParseBool]: 0, f, F, FALSE, false and False.
+	FalsyValues []string
+	// Strings that are decoded as null, e.g. "", "NULL" or "N/A". Strings are never null by default.
+	NullValues []string
+	// Trims leading and trailing white spaces of strings.
+	TrimSpace bool
+	// Leading and trailing characters that are trimmed from strings, e.g. quotes.
+	TrimCutset string
+	// Matches the truthy, falsy and null values case-insensitively.
+	CaseInsensitive bool
+}
+
+// normalizeString trims the string and reports whether it's a null value.
+func (d *Decoder) normalizeString(value string) (string, bool) {
+	if d.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+
+	if d.TrimCutset != "" {
+		value = strings.Trim(value, d.TrimCutset)
+	}
+
+	return value, d.matchValue(d.NullValues, value)
+}
+
+// nullableString trims the string and returns nil if it's a null value.
+func (d *Decoder) nullableString(value string) *string {
+	value, isNull := d.normalizeString(value)
+	if isNull {
+		return nil
+	}
+
+	return &value
+}
+
+// rewritesStrings reports whether decoded strings may differ from the input.
+func (d *Decoder) rewritesStrings() bool {
+	return d.TrimSpace || d.TrimCutset != "" || len(d.NullValues) > 0
+}
+
+func (d *Decoder) matchValue(values []string, value string) bool {
+	if !d.CaseInsensitive {
+		return slices.Contains(values, value)
+	}
+
+	return slices.ContainsFunc(values, func(item string) bool {
+		return strings.EqualFold(item, value)
+	})
+}
+
+func (d *Decoder) parseNullableBool(value string) (*bool, error) {
+	value, isNull := d.normalizeString(value)
+	if isNull {
+		return nil, nil
+	}
+
+	truthyValues := d.TruthyValues
+	if truthyValues == nil {
+		truthyValues = defaultTruthyValues
+	}
+
+	if d.matchValue(truthyValues, value) {
+		return new(true), nil
+	}
+
+	falsyValues := d.FalsyValues
+	if falsyValues == nil {
+		falsyValues = defaultFalsyValues
+	}
+
+	if d.matchValue(falsyValues, value) {
+		return new(false), nil
+	}
+
+	return nil, ErrMalformedBoolean
+}
+
+func (d *Decoder) parseBool(value string) (bool, error) {
+	result, err := d.parseNullableBool(value)
+
+	return requireDecodedValue(result, err, ErrBooleanNull)
+}
@@ -0,0 +1,257 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newCSVDecoder() *Decoder {
+	return &Decoder{
+		TruthyValues:    []string{"yes", "on", "y"},
+		FalsyValues:     []string{"no", "off", "n"},
+		NullValues:      []string{"", "null", "n/a"},
+		TrimSpace:       true,
+		CaseInsensitive: true,
+	}
+}
+
+func TestDecoder_Boolean(t *testing.T) {
+	decoder := newCSVDecoder()
+
+	for value, expected := range map[string]bool{"yes": true, " ON ": true, "Y": true, "No": false, "off\t": false} {
+		result, err := decoder.DecodeBoolean(value)
+		if err != nil {
+			t.Fatalf("expected nil error of %q, got: %s", value, err)
+		}
+
+		assertEqual(t, expected, result)
+	}
+
+	for _, value := range []string{"", " NULL ", "N/A"} {
+		result, err := decoder.DecodeNullableBoolean(value)
+		if err != nil || result != nil {
+			t.Fatalf("expected nil of %q, got: %v, %v", value, result, err)
+		}
+
+		_, err = decoder.DecodeBoolean(value)
+		if !errors.Is(err, ErrBooleanNull) {
+			t.Fatalf("expected ErrBooleanNull of %q, got: %v", value, err)
+		}
+	}
+
+	for _, value := range []any{"true", "1", "maybe"} {
+		_, err := decoder.DecodeBoolean(value)
+		if !errors.Is(err, ErrMalformedBoolean) {
+			t.Errorf("expected ErrMalformedBoolean of %v, got: %v", value, err)
+		}
+	}
+
+	_, err := decoder.AsBoolean("yes")
+	if !errors.Is(err, ErrMalformedBoolean) {
+		t.Fatalf("expected ErrMalformedBoolean, got: %v", err)
+	}
+
+	results, err := decoder.DecodeBooleanSlice([]string{"Y", "n", "on"})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	assertDeepEqual(t, []bool{true, false, true}, results)
+
+	_, err = decoder.DecodeBooleanSlice([]any{"y", ""})
+	assertError(t, err, "failed to decode field /1: boolean value must not be null")
+}
+
+func TestDecoder_Default(t *testing.T) {
+	decoder := &Decoder{}
+
+	result, err := decoder.DecodeBoolean("True")
+	if err != nil || !result {
+		t.Fatalf("expected true, got: %v, %v", result, err)
+	}
+
+	for _, value := range []string{"yes", "tRUE", " true", ""} {
+		_, err = decoder.DecodeBoolean(value)
+		if !errors.Is(err, ErrMalformedBoolean) {
+			t.Errorf("expected ErrMalformedBoolean of %q, got: %v", value, err)
+		}
+	}
+
+	str, err := decoder.DecodeString(" a ")
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	assertEqual(t, " a ", str)
+
+	decoder = &Decoder{TruthyValues: []string{"Yes"}}
+
+	result, err = decoder.DecodeBoolean("false")
+	if err != nil || result {
+		t.Fatalf("expected false, got: %v, %v", result, err)
+	}
+
+	_, err = decoder.DecodeBoolean("yes")
+	if !errors.Is(err, ErrMalformedBoolean) {
+		t.Fatalf("expected ErrMalformedBoolean, got: %v", err)
+	}
+}
+
+func TestDecoder_Number(t *testing.T) {
+	decoder := newCSVDecoder()
+
+	result, err := DecodeNumberWith[int](decoder, " 42 ")
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	assertEqual(t, 42, result)
+
+	nullable, err := DecodeNullableNumberWith[float64](decoder, new("NULL"))
+	if err != nil || nullable != nil {
+		t.Fatalf("expected nil, got: %v, %v", nullable, err)
+	}
+
+	_, err = DecodeNumberReflectionWith[int](decoder, reflect.ValueOf(""))
+	if !errors.Is(err, ErrNumberNull) {
+		t.Fatalf("expected ErrNumberNull, got: %v", err)
+	}
+
+	_, err = AsNumberWith[int](decoder, "1")
+	if !errors.Is(err, ErrMalformedNumber) {
+		t.Fatalf("expected ErrMalformedNumber, got: %v", err)
+	}
+
+	checked, err := DecodeNullableNumberCheckedWith[uint8](decoder, "n/a")
+	if err != nil || checked != nil {
+		t.Fatalf("expected nil, got: %v, %v", checked, err)
+	}
+
+	_, err = DecodeNumberCheckedWith[uint8](decoder, " 256")
+	if !errors.Is(err, ErrNumberOutOfRange) {
+		t.Fatalf("expected ErrNumberOutOfRange, got: %v", err)
+	}
+
+	_, err = DecodeNumberSliceWith[int](decoder, []string{"1", " ", "x"})
+
+	var decodeErrs DecodeFieldErrors
+
+	if !errors.As(err, &decodeErrs) || len(decodeErrs) != 2 ||
+		!errors.Is(decodeErrs[0], ErrNumberNull) || !errors.Is(decodeErrs[1], ErrMalformedNumber) {
+		t.Fatalf("expected null and malformed errors, got: %v", err)
+	}
+}
+
+func TestDecoder_String(t *testing.T) {
+	decoder := &Decoder{NullValues: []string{""}, TrimSpace: true, TrimCutset: `"`}
+
+	result, err := decoder.DecodeString(` "a b" `)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	assertEqual(t, "a b", result)
+
+	nullable, err := decoder.DecodeNullableString(`""`)
+	if err != nil || nullable != nil {
+		t.Fatalf("expected nil, got: %v, %v", nullable, err)
+	}
+
+	results, err := decoder.DecodeStringSlice([]string{" a", `"b"`})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	assertDeepEqual(t, []string{"a", "b"}, results)
+
+	_, err = decoder.DecodeStringSlice([]string{"a", " "})
+	assertError(t, err, "failed to decode field /1: string value must not be null")
+}
+
+func TestDecoder_KnownTypes(t *testing.T) {
+	decoder := newCSVDecoder()
+
+	for _, value := range []string{"", "null"} {
+		timeResult, err := decoder.DecodeNullableTime(value)
+		if err != nil || timeResult != nil {
+			t.Fatalf("expected nil time, got: %v, %v", timeResult, err)
+		}
+
+		durationResult, err := decoder.DecodeNullableDuration(value)
+		if err != nil || durationResult != nil {
+			t.Fatalf("expected nil duration, got: %v, %v", durationResult, err)
+		}
+
+		urlResult, err := decoder.DecodeNullableURL(value)
+		if err != nil || urlResult != nil {
+			t.Fatalf("expected nil URL, got: %v, %v", urlResult, err)
+		}
+	}
+
+	_, err := decoder.DecodeTimeSlice([]any{"2026-01-02", "N/A"})
+	if !errors.Is(err, ErrTimeNull) {
+		t.Fatalf("expected ErrTimeNull, got: %v", err)
+	}
+
+	duration, err := decoder.DecodeDuration(" 1m ")
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	assertEqual(t, time.Minute, duration)
+}
+
+func TestDecodeStructWith(t *testing.T) {
+	type record struct {
+		Name    string         `json:"name"`
+		Active  bool           `json:"active"`
+		Count   *int           `json:"count"`
+		Ratio   float64        `json:"ratio"`
+		Timeout *time.Duration `json:"timeout"`
+		Tags    []string       `json:"tags"`
+	}
+
+	result, err := DecodeStructWith[record](newCSVDecoder(), map[string]any{
+		"name":    " alice ",
+		"active":  "Yes",
+		"count":   "",
+		"ratio":   " 0.5",
+		"timeout": "N/A",
+		"tags":    []any{" a ", "null"},
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	assertDeepEqual(t, record{
+		Name:   "alice",
+		Active: true,
+		Ratio:  0.5,
+		Tags:   []string{"a", ""},
+	}, result)
+
+	_, err = DecodeStruct[record](map[string]any{"active": "Yes", "count": ""})
+
+	var decodeErrs DecodeFieldErrors
+
+	if !errors.As(err, &decodeErrs) || len(decodeErrs) != 2 || decodeErrs[0].Pointer != "/active" ||
+		decodeErrs[1].Pointer != "/count" {
+		t.Fatalf("expected errors of the default decoder, got: %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/relychan/goutils/httperror"
+)
+
+// DecodeFieldError occurs when a field or an element of a loosely typed value can't be decoded.
+type DecodeFieldError struct {
+	// The JSON Pointer of the field or element, e.g. /servers/0/port. It's empty for the root value.
+	Pointer string
+	// The underlying error of the decoder.
+	Err error
+}
+
+// Error implements the error interface for DecodeFieldError.
+func (e DecodeFieldError) Error() string {
+	if e.Pointer == "" {
+		return "failed to decode: " + e.Err.Error()
+	}
+
+	return "failed to decode field " + e.Pointer + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error of the decoder.
+func (e DecodeFieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError converts the error to a validation error, so API handlers can return it directly.
+func (e DecodeFieldError) ValidationError() httperror.ValidationError {
+	return httperror.ValidationError{
+		Detail:  e.Err.Error(),
+		Pointer: e.Pointer,
+	}
+}
+
+// DecodeFieldErrors is a list of errors of every failing field or element of a loosely typed value.
+type DecodeFieldErrors []DecodeFieldError
+
+// Error implements the error interface for DecodeFieldErrors.
+func (e DecodeFieldErrors) Error() string {
+	messages := make([]string, len(e))
+
+	for i, item := range e {
+		messages[i] = item.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the list of errors.
+func (e DecodeFieldErrors) Unwrap() []error {
+	result := make([]error, len(e))
+
+	for i, item := range e {
+		result[i] = item
+	}
+
+	return result
+}
+
+// ValidationErrors converts the errors to validation errors, so API handlers can return them directly.
+func (e DecodeFieldErrors) ValidationErrors() []httperror.ValidationError {
+	result := make([]httperror.ValidationError, len(e))
+
+	for i, item := range e {
+		result[i] = item.ValidationError()
+	}
+
+	return result
+}
+
+// add adds the error at the pointer. Errors of nested values are flattened with their pointers
+// relative to the pointer.
+func (e *DecodeFieldErrors) add(pointer string, err error) {
+	var fieldErrs DecodeFieldErrors
+
+	if errors.As(err, &fieldErrs) {
+		for _, item := range fieldErrs {
+			*e = append(*e, DecodeFieldError{Pointer: pointer + item.Pointer, Err: item.Err})
+		}
+
+		return
+	}
+
+	var fieldErr DecodeFieldError
+
+	if errors.As(err, &fieldErr) {
+		*e = append(*e, DecodeFieldError{Pointer: pointer + fieldErr.Pointer, Err: fieldErr.Err})
+
+		return
+	}
+
+	*e = append(*e, DecodeFieldError{Pointer: pointer, Err: err})
+}
+
+// decodeElements decodes every element of a slice with its index.
+// Errors of all failing elements are collected in [DecodeFieldErrors] with the index as the JSON Pointer.
+func decodeElements[T any](length int, decodeElem func(index int) (T, error)) ([]T, error) {
+	results := make([]T, length)
+
+	var errs DecodeFieldErrors
+
+	for i := range length {
+		result, err := decodeElem(i)
+		if err != nil {
+			errs.add("/"+strconv.Itoa(i), err)
+
+			continue
+		}
+
+		results[i] = result
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return results, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/relychan/goutils/httperror"
+)
+
+func TestDecodeFieldErrors(t *testing.T) {
+	t.Run("slice", func(t *testing.T) {
+		_, err := DecodeNumberSlice[int]([]any{1, "a", nil, 4})
+
+		var decodeErrs DecodeFieldErrors
+
+		if !errors.As(err, &decodeErrs) || len(decodeErrs) != 2 {
+			t.Fatalf("expected 2 errors, got: %v", err)
+		}
+
+		assertEqual(t, "/1", decodeErrs[0].Pointer)
+		assertEqual(t, "/2", decodeErrs[1].Pointer)
+
+		if !errors.Is(err, ErrMalformedNumber) || !errors.Is(err, ErrNumberNull) {
+			t.Fatalf("expected ErrMalformedNumber and ErrNumberNull, got: %v", err)
+		}
+	})
+
+	t.Run("pointer_slice", func(t *testing.T) {
+		_, err := DecodeStringSlice([]*string{nil, new("a"), nil})
+		assertError(t, err, "failed to decode field /0: string value must not be null\nfailed to decode field /2: string value must not be null")
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		type server struct {
+			Port    int   `json:"port"`
+			Weights []int `json:"weights"`
+		}
+
+		_, err := DecodeStruct[struct {
+			Servers []server `json:"servers"`
+			Ratio   float64  `json:"ratio"`
+		}](map[string]any{
+			"servers": []any{
+				map[string]any{"port": 80, "weights": []any{1}},
+				map[string]any{"port": "x", "weights": []any{1, "b"}},
+			},
+			"ratio": true,
+		})
+
+		var decodeErrs DecodeFieldErrors
+
+		if !errors.As(err, &decodeErrs) || len(decodeErrs) != 3 {
+			t.Fatalf("expected 3 errors, got: %v", err)
+		}
+
+		assertDeepEqual(t, []httperror.ValidationError{
+			{Detail: "malformed number", Pointer: "/servers/1/port"},
+			{Detail: "malformed number", Pointer: "/servers/1/weights/1"},
+			{Detail: "malformed number; got: bool", Pointer: "/ratio"},
+		}, decodeErrs.ValidationErrors())
+	})
+
+	t.Run("root", func(t *testing.T) {
+		err := DecodeFieldError{Err: ErrNumberNull}
+
+		assertEqual(t, "failed to decode: number value must not be null", err.Error())
+		assertEqual(t, "", err.ValidationError().Pointer)
+	})
+}
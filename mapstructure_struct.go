@@ -0,0 +1,500 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"cmp"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	errUnsupportedDecodeType = errors.New("type isn't supported by the decoder")
+	errInvalidInlineField    = errors.New("inline field must be a struct, a pointer to a struct or a map with string keys")
+)
+
+// structFieldTagKeys are the tags of struct fields in order of precedence.
+var structFieldTagKeys = []string{"mapstructure", "json", "yaml"}
+
+// DecodeStruct decodes a loosely typed object, e.g. query params, GraphQL variables or a generic JSON document,
+// to a value of the type T, which is usually a struct.
+//
+// Fields are matched by the name of the mapstructure, json or yaml tag in order, or by the field name,
+// falling back to a case-insensitive match. Embedded structs and fields with the squash or inline option
+// are flattened. A map field with string keys and the squash or inline option gets the keys that don't match
+// any other field. Unexported fields are ignored. Nested structs, pointers, maps and slices are decoded recursively.
+// Strings, numbers, booleans, times, durations and URLs are converted with the Decode* functions,
+// e.g. numbers and booleans may be strings and times may be Unix timestamps.
+// Numbers are checked as [DecodeNumberChecked] does, so they are never truncated or wrapped.
+// String values of types with UnmarshalText methods, e.g. [Duration] and [Slug], are decoded with the method,
+// and other values of types with UnmarshalJSON methods are decoded from their JSON encoding.
+//
+// Every failing field is collected in [DecodeFieldErrors] with the JSON Pointer of the field.
+func DecodeStruct[T any](value map[string]any) (T, error) {
+	return DecodeStructWith[T](defaultDecoder, value)
+}
+
+// DecodeStructWith decodes a loosely typed object to a value of the type T with the configuration of the decoder.
+// Strings that are null values of the decoder are decoded as null. See [DecodeStruct] for details.
+func DecodeStructWith[T any](decoder *Decoder, value map[string]any) (T, error) {
+	var result T
+
+	if value == nil {
+		return result, nil
+	}
+
+	structDec := &structDecoder{decoder: decoder}
+	structDec.decode(reflect.ValueOf(&result).Elem(), value, "")
+
+	if len(structDec.errs) > 0 {
+		var empty T
+
+		return empty, structDec.errs
+	}
+
+	return result, nil
+}
+
+type structDecoder struct {
+	decoder *Decoder
+	errs    DecodeFieldErrors
+}
+
+func (d *structDecoder) addError(pointer string, err error) {
+	d.errs.add(pointer, err)
+}
+
+// decode decodes the value to the settable target.
+func (d *structDecoder) decode(target reflect.Value, value any, pointer string) { //nolint:cyclop
+	source, ok := UnwrapPointerFromReflectValue(reflect.ValueOf(value))
+	if !ok {
+		target.SetZero()
+
+		return
+	}
+
+	if source.Kind() == reflect.String {
+		str, isNull := d.decoder.normalizeString(source.String())
+		if isNull {
+			target.SetZero()
+
+			return
+		}
+
+		source = reflect.ValueOf(str).Convert(source.Type())
+	}
+
+	if source.Type().AssignableTo(target.Type()) {
+		target.Set(source)
+
+		return
+	}
+
+	if target.Kind() == reflect.Pointer {
+		elem := reflect.New(target.Type().Elem())
+		d.decode(elem.Elem(), source.Interface(), pointer)
+		target.Set(elem)
+
+		return
+	}
+
+	if ok, err := d.decoder.decodeKnownType(target, source); ok {
+		if err != nil {
+			d.addError(pointer, err)
+		}
+
+		return
+	}
+
+	if ok, err := decodeWithUnmarshaler(target, source.Interface()); ok {
+		if err != nil {
+			d.addError(pointer, err)
+		}
+
+		return
+	}
+
+	var err error
+
+	switch target.Kind() {
+	case reflect.String:
+		var result string
+
+		result, err = d.decoder.DecodeStringReflection(source)
+		if err == nil {
+			target.SetString(result)
+		}
+	case reflect.Bool:
+		var result bool
+
+		result, err = d.decoder.DecodeBooleanReflection(source)
+		if err == nil {
+			target.SetBool(result)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		err = d.decoder.decodeCheckedNumberTo(target, source)
+	case reflect.Slice, reflect.Array:
+		d.decodeSlice(target, source, pointer)
+	case reflect.Map:
+		d.decodeMap(target, source, pointer)
+	case reflect.Struct:
+		d.decodeStruct(target, source, pointer)
+	default:
+		err = fmt.Errorf("%w: %s <- %s", errUnsupportedDecodeType, target.Type(), source.Type())
+	}
+
+	if err != nil {
+		d.addError(pointer, err)
+	}
+}
+
+func (d *structDecoder) decodeSlice(target reflect.Value, source reflect.Value, pointer string) {
+	if source.Kind() != reflect.Slice && source.Kind() != reflect.Array {
+		d.addError(pointer, fmt.Errorf("%w; got: %s", ErrMalformedSlice, source.Type()))
+
+		return
+	}
+
+	length := source.Len()
+
+	if target.Kind() == reflect.Slice {
+		target.Set(reflect.MakeSlice(target.Type(), length, length))
+	} else {
+		// Extra elements are ignored and missing elements are zero, the same as encoding/json.
+		target.SetZero()
+		length = min(length, target.Len())
+	}
+
+	for i := range length {
+		d.decode(target.Index(i), source.Index(i).Interface(), appendJSONPointer(pointer, strconv.Itoa(i)))
+	}
+}
+
+func (d *structDecoder) decodeMap(target reflect.Value, source reflect.Value, pointer string) {
+	if source.Kind() != reflect.Map {
+		d.addError(pointer, fmt.Errorf("%w; got: %s", ErrMalformedMap, source.Type()))
+
+		return
+	}
+
+	targetType := target.Type()
+	result := reflect.MakeMapWithSize(targetType, source.Len())
+
+	for token, key := range sortedMapKeys(source) {
+		keyPointer := appendJSONPointer(pointer, token)
+		targetKey := reflect.New(targetType.Key()).Elem()
+		targetElem := reflect.New(targetType.Elem()).Elem()
+
+		d.decode(targetKey, key.Interface(), keyPointer)
+		d.decode(targetElem, source.MapIndex(key).Interface(), keyPointer)
+		result.SetMapIndex(targetKey, targetElem)
+	}
+
+	target.Set(result)
+}
+
+func (d *structDecoder) decodeStruct(target reflect.Value, source reflect.Value, pointer string) {
+	values, ok := source.Interface().(map[string]any)
+	if !ok {
+		if source.Kind() != reflect.Map || source.Type().Key().Kind() != reflect.String {
+			d.addError(pointer, fmt.Errorf("%w; got: %s", ErrMalformedMap, source.Type()))
+
+			return
+		}
+
+		values = make(map[string]any, source.Len())
+
+		for mapIter := source.MapRange(); mapIter.Next(); {
+			values[mapIter.Key().String()] = mapIter.Value().Interface()
+		}
+	}
+
+	d.decodeFields(target, values, pointer)
+}
+
+// structFields tracks the keys that are decoded to fields of a struct and its flattened fields,
+// so the remaining keys are decoded to inline maps.
+type structFields struct {
+	usedKeys   map[string]bool
+	inlineMaps []reflect.Value
+}
+
+// decodeFields decodes the values to fields of the struct. Flattened fields are decoded from the same values,
+// and inline maps get the values that aren't decoded to any field.
+func (d *structDecoder) decodeFields(target reflect.Value, values map[string]any, pointer string) {
+	fields := &structFields{usedKeys: make(map[string]bool, len(values))}
+	d.decodeFieldsTo(target, values, pointer, fields)
+
+	if len(fields.inlineMaps) == 0 {
+		return
+	}
+
+	remaining := make(map[string]any)
+
+	for key, value := range values {
+		if !fields.usedKeys[key] {
+			remaining[key] = value
+		}
+	}
+
+	if len(remaining) == 0 {
+		return
+	}
+
+	for _, inlineMap := range fields.inlineMaps {
+		d.decodeMap(inlineMap, reflect.ValueOf(remaining), pointer)
+	}
+}
+
+func (d *structDecoder) decodeFieldsTo(target reflect.Value, values map[string]any, pointer string, fields *structFields) {
+	for field := range target.Type().Fields() {
+		name, flatten, skip := parseStructFieldTag(field)
+		if skip {
+			continue
+		}
+
+		fieldValue := target.FieldByIndex(field.Index)
+
+		if flatten || (field.Anonymous && name == "" && isEmbeddedStruct(field.Type)) {
+			d.decodeFlattenedField(field, fieldValue, values, pointer, fields)
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		key, value, ok := lookupStructFieldValue(values, name)
+		if ok {
+			fields.usedKeys[key] = true
+			d.decode(fieldValue, value, appendJSONPointer(pointer, key))
+		}
+	}
+}
+
+// decodeFlattenedField decodes the values to an embedded field or a field with the squash or inline option.
+// Structs and pointers to structs are decoded from the same values, and maps with string keys are collected
+// to get the remaining values.
+func (d *structDecoder) decodeFlattenedField(
+	field reflect.StructField,
+	fieldValue reflect.Value,
+	values map[string]any,
+	pointer string,
+	fields *structFields,
+) {
+	// Unexported fields are ignored, except embedded structs whose exported fields are promoted.
+	if !field.IsExported() && !field.Anonymous {
+		return
+	}
+
+	fieldType := field.Type
+
+	switch {
+	case fieldType.Kind() == reflect.Struct:
+		d.decodeFieldsTo(fieldValue, values, pointer, fields)
+	case fieldType.Kind() == reflect.Pointer && fieldType.Elem().Kind() == reflect.Struct:
+		if !fieldValue.CanSet() {
+			return
+		}
+
+		if fieldValue.IsNil() {
+			fieldValue.Set(reflect.New(fieldType.Elem()))
+		}
+
+		d.decodeFieldsTo(fieldValue.Elem(), values, pointer, fields)
+	case fieldType.Kind() == reflect.Map && fieldType.Key().Kind() == reflect.String:
+		if fieldValue.CanSet() {
+			fields.inlineMaps = append(fields.inlineMaps, fieldValue)
+		}
+	default:
+		d.addError(pointer, fmt.Errorf("%w: %s %s", errInvalidInlineField, field.Name, fieldType))
+	}
+}
+
+// decodeCheckedNumberTo decodes the number to the target without overflowing or losing precision.
+func (d *Decoder) decodeCheckedNumberTo(target reflect.Value, source reflect.Value) error {
+	result, err := d.decodeCheckedNumberReflection(source, target.Type())
+	if err != nil || result == nil {
+		return err
+	}
+
+	switch result.kind {
+	case reflect.Int64:
+		target.SetInt(result.int)
+	case reflect.Uint64:
+		target.SetUint(result.uint)
+	default:
+		target.SetFloat(result.float)
+	}
+
+	return nil
+}
+
+// decodeKnownType decodes times, durations and URLs with their Decode* functions.
+func (d *Decoder) decodeKnownType(target reflect.Value, source reflect.Value) (bool, error) {
+	var (
+		result any
+		err    error
+	)
+
+	switch target.Type() {
+	case timeType:
+		result, err = d.DecodeTimeReflection(source)
+	case durationType:
+		result, err = d.DecodeDurationReflection(source)
+	case urlType:
+		var parsedURL *url.URL
+
+		parsedURL, err = d.DecodeURLReflection(source)
+		if err == nil {
+			result = *parsedURL
+		}
+	default:
+		return false, nil
+	}
+
+	if err == nil {
+		target.Set(reflect.ValueOf(result))
+	}
+
+	return true, err
+}
+
+// decodeWithUnmarshaler decodes the value with the UnmarshalText or UnmarshalJSON method of the target if exists.
+func decodeWithUnmarshaler(target reflect.Value, value any) (bool, error) {
+	if !target.CanAddr() {
+		return false, nil
+	}
+
+	receiver := target.Addr().Interface()
+
+	if text, isString := value.(string); isString {
+		if unmarshaler, ok := receiver.(encoding.TextUnmarshaler); ok {
+			return true, unmarshaler.UnmarshalText([]byte(text))
+		}
+	}
+
+	unmarshaler, ok := receiver.(json.Unmarshaler)
+	if !ok {
+		return false, nil
+	}
+
+	rawBytes, err := json.Marshal(value)
+	if err != nil {
+		return true, err
+	}
+
+	return true, unmarshaler.UnmarshalJSON(rawBytes)
+}
+
+// parseStructFieldTag returns the name of the field in the first tag of [structFieldTagKeys],
+// and whether the field is flattened or skipped.
+func parseStructFieldTag(field reflect.StructField) (string, bool, bool) {
+	for _, key := range structFieldTagKeys {
+		tag, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" && options == "" {
+			return "", false, true
+		}
+
+		var flatten bool
+
+		for option := range strings.SplitSeq(options, ",") {
+			if option == "squash" || option == "inline" {
+				flatten = true
+			}
+		}
+
+		return name, flatten, false
+	}
+
+	return "", false, false
+}
+
+// isEmbeddedStruct checks if the fields of an embedded type are promoted to the parent object.
+func isEmbeddedStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	receiver := reflect.PointerTo(typ)
+
+	return !receiver.Implements(jsonUnmarshalerType) && !receiver.Implements(textUnmarshalerType)
+}
+
+// lookupStructFieldValue finds the value of the field name, falling back to a case-insensitive match.
+func lookupStructFieldValue(values map[string]any, name string) (string, any, bool) {
+	if value, ok := values[name]; ok {
+		return name, value, true
+	}
+
+	for key, value := range values {
+		if strings.EqualFold(key, name) {
+			return key, value, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// sortedMapKeys returns the keys of the map with their JSON Pointer tokens in order,
+// so errors are reported in a stable order.
+func sortedMapKeys(source reflect.Value) iter.Seq2[string, reflect.Value] {
+	type mapKey struct {
+		token string
+		value reflect.Value
+	}
+
+	keys := make([]mapKey, 0, source.Len())
+
+	for mapIter := source.MapRange(); mapIter.Next(); {
+		keys = append(keys, mapKey{token: fmt.Sprint(mapIter.Key().Interface()), value: mapIter.Key()})
+	}
+
+	slices.SortFunc(keys, func(a, b mapKey) int {
+		return cmp.Compare(a.token, b.token)
+	})
+
+	return func(yield func(string, reflect.Value) bool) {
+		for _, key := range keys {
+			if !yield(key.token, key.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type decodeStructBase struct {
+	Owner string `json:"owner"`
+}
+
+type decodeStructServer struct {
+	Host string `yaml:"host"`
+	Port int    `json:"port"`
+}
+
+type decodeStructConfig struct {
+	decodeStructBase
+
+	ID       Slug                          `mapstructure:"id"       json:"identifier"`
+	Name     string                        `json:"name"`
+	Enabled  bool                          `json:"enabled"`
+	Ratio    *float64                      `json:"ratio"`
+	Timeout  Duration                      `json:"timeout"`
+	StartsAt time.Time                     `json:"startsAt"`
+	Origins  AllOrListString               `json:"origins"`
+	Tags     []string                      `json:"tags"`
+	Ports    [2]uint16                     `json:"ports"`
+	Servers  []decodeStructServer          `json:"servers"`
+	Labels   map[string]int                `json:"labels"`
+	Primary  *decodeStructServer           `json:"primary"`
+	Extra    any                           `json:"extra"`
+	Limits   map[int]Duration              `json:"limits"`
+	Nested   struct{ Level int8 }          `json:"nested"`
+	Squashed decodeStructServer            `mapstructure:",squash"`
+	Skipped  string                        `json:"-"`
+	Mixed    map[string]decodeStructServer `json:"mixed"`
+}
+
+type decodeStructInline struct {
+	Name   string              `json:"name"`
+	Server *decodeStructServer `mapstructure:",squash"`
+	Extra  map[string]any      `yaml:",inline"`
+}
+
+type decodeStructUnexported struct {
+	Name   string             `json:"name"`
+	server decodeStructServer `mapstructure:",squash"`
+	extra  map[string]any     `yaml:",inline"`
+}
+
+func TestDecodeStruct(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		result, err := DecodeStruct[decodeStructConfig](map[string]any{
+			"owner":    "core",
+			"id":       "my-app",
+			"NAME":     "app",
+			"enabled":  "true",
+			"ratio":    "0.5",
+			"timeout":  "1h30m",
+			"startsAt": "2026-01-02T03:04:05Z",
+			"origins":  []any{"a", "b"},
+			"tags":     []string{"x"},
+			"ports":    []any{80, "443", 8080},
+			"servers":  []any{map[string]any{"host": "localhost", "port": 8080.0}},
+			"labels":   map[string]any{"a": "1", "b": 2},
+			"primary":  map[string]any{"host": "primary"},
+			"extra":    []any{1, "2"},
+			"limits":   map[string]any{"1": "1s"},
+			"nested":   map[string]any{"level": 3},
+			"host":     "squashed",
+			"Skipped":  "value",
+			"mixed":    map[string]map[string]string{"a": {"port": "1"}},
+		})
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		expected := decodeStructConfig{
+			decodeStructBase: decodeStructBase{Owner: "core"},
+			ID:               "my-app",
+			Name:             "app",
+			Enabled:          true,
+			Ratio:            new(0.5),
+			Timeout:          Duration(90 * time.Minute),
+			StartsAt:         time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Origins:          NewStringList([]string{"a", "b"}),
+			Tags:             []string{"x"},
+			Ports:            [2]uint16{80, 443},
+			Servers:          []decodeStructServer{{Host: "localhost", Port: 8080}},
+			Labels:           map[string]int{"a": 1, "b": 2},
+			Primary:          &decodeStructServer{Host: "primary"},
+			Extra:            []any{1, "2"},
+			Limits:           map[int]Duration{1: Duration(time.Second)},
+			Nested:           struct{ Level int8 }{Level: 3},
+			Squashed:         decodeStructServer{Host: "squashed"},
+			Mixed:            map[string]decodeStructServer{"a": {Port: 1}},
+		}
+
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("expected %+v, got: %+v", expected, result)
+		}
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		result, err := DecodeStruct[*decodeStructServer](nil)
+		if err != nil || result != nil {
+			t.Fatalf("expected nil result, got: %v, %v", result, err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := DecodeStruct[decodeStructConfig](map[string]any{
+			"id":       "my app",
+			"enabled":  "maybe",
+			"timeout":  10,
+			"startsAt": "yesterday",
+			"tags":     "x",
+			"servers":  []any{map[string]any{"port": "a"}, "b"},
+			"labels":   map[string]any{"a/b": true},
+			"primary":  []any{},
+			"limits":   map[string]any{"x": "1s"},
+		})
+
+		var decodeErrs DecodeFieldErrors
+
+		if !errors.As(err, &decodeErrs) {
+			t.Fatalf("expected DecodeFieldErrors, got: %v", err)
+		}
+
+		expected := []struct {
+			Pointer string
+			Err     error
+		}{
+			{"/id", ErrInvalidSlug},
+			{"/enabled", ErrMalformedBoolean},
+			{"/timeout", errInvalidJSONString},
+			{"/startsAt", nil},
+			{"/tags", ErrMalformedSlice},
+			{"/servers/0/port", ErrMalformedNumber},
+			{"/servers/1", ErrMalformedMap},
+			{"/labels/a~1b", ErrMalformedNumber},
+			{"/primary", ErrMalformedMap},
+			{"/limits/x", ErrMalformedNumber},
+		}
+
+		if len(decodeErrs) != len(expected) {
+			t.Fatalf("expected %d errors, got: %s", len(expected), err)
+		}
+
+		for i, item := range expected {
+			if decodeErrs[i].Pointer != item.Pointer || (item.Err != nil && !errors.Is(decodeErrs[i], item.Err)) {
+				t.Errorf("expected %s: %v at %d, got: %s", item.Pointer, item.Err, i, decodeErrs[i])
+			}
+		}
+
+		if !errors.Is(err, ErrInvalidSlug) {
+			t.Errorf("expected the joined errors to match ErrInvalidSlug")
+		}
+
+		assertEqual(
+			t,
+			`failed to decode field /id: invalid slug, character ' ' is not allowed`,
+			decodeErrs[0].Error(),
+		)
+	})
+
+	t.Run("inline_map", func(t *testing.T) {
+		result, err := DecodeStruct[decodeStructInline](map[string]any{
+			"name":  "app",
+			"HOST":  "localhost",
+			"port":  "8080",
+			"debug": true,
+		})
+		assertNilError(t, err)
+		assertDeepEqual(t, decodeStructInline{
+			Name:   "app",
+			Server: &decodeStructServer{Host: "localhost", Port: 8080},
+			Extra:  map[string]any{"debug": true},
+		}, result)
+
+		result, err = DecodeStruct[decodeStructInline](map[string]any{"name": "app"})
+		assertNilError(t, err)
+
+		if result.Extra != nil {
+			t.Fatalf("expected nil inline map, got: %v", result.Extra)
+		}
+	})
+
+	t.Run("unexported_flattened_fields", func(t *testing.T) {
+		result, err := DecodeStruct[decodeStructUnexported](map[string]any{
+			"name": "app",
+			"host": "localhost",
+		})
+		assertNilError(t, err)
+		assertDeepEqual(t, decodeStructUnexported{Name: "app"}, result)
+	})
+
+	t.Run("invalid_inline_field", func(t *testing.T) {
+		_, err := DecodeStruct[struct {
+			Port int `yaml:",inline"`
+		}](map[string]any{"port": 1})
+		if !errors.Is(err, errInvalidInlineField) {
+			t.Fatalf("expected errInvalidInlineField, got: %v", err)
+		}
+
+		_, err = DecodeStruct[struct {
+			Extra map[string]int `yaml:",inline"`
+		}](map[string]any{"a": "x"})
+
+		var decodeErrs DecodeFieldErrors
+
+		if !errors.As(err, &decodeErrs) || len(decodeErrs) != 1 || decodeErrs[0].Pointer != "/a" {
+			t.Fatalf("expected an error of /a, got: %v", err)
+		}
+	})
+}
@@ -136,10 +136,10 @@ func TestDecodeBooleanSlice(t *testing.T) {
 	assertError(t, err, "malformed boolean slice; got: struct")
 
 	_, err = DecodeBooleanSlice([]any{nil})
-	assertError(t, err, "failed to decode boolean element at 0: boolean value must not be null")
+	assertError(t, err, "failed to decode field /0: boolean value must not be null")
 
 	_, err = DecodeBooleanSlice(&[]any{nil})
-	assertError(t, err, "failed to decode boolean element at 0: boolean value must not be null")
+	assertError(t, err, "failed to decode field /0: boolean value must not be null")
 }
 
 func TestDecodeNullableBooleanSlice(t *testing.T) {
@@ -164,7 +164,7 @@ func TestDecodeNullableBooleanSlice(t *testing.T) {
 	assertDeepEqual(t, []bool{true}, *value)
 
 	_, err = DecodeNullableBooleanSlice([]any{nil})
-	assertError(t, err, "failed to decode boolean element at 0: boolean value must not be null")
+	assertError(t, err, "failed to decode field /0: boolean value must not be null")
 
 	value, err = DecodeNullableBooleanSlice(nil)
 	assertNilError(t, err)
@@ -246,10 +246,10 @@ func TestDecodeStringSlice(t *testing.T) {
 	assertError(t, err, "malformed string slice; got: struct")
 
 	_, err = DecodeStringSlice([]any{nil})
-	assertError(t, err, "failed to decode string at 0: string value must not be null")
+	assertError(t, err, "failed to decode field /0: string value must not be null")
 
 	_, err = DecodeStringSlice(&[]any{nil})
-	assertError(t, err, "failed to decode element at 0: string value must not be null")
+	assertError(t, err, "failed to decode field /0: string value must not be null")
 }
 
 func TestDecodeNumber(t *testing.T) {
@@ -608,7 +608,7 @@ func TestAsBooleanSlice(t *testing.T) {
 
 	// nil element in slice rejected
 	_, err = AsBooleanSlice([]any{nil})
-	assertError(t, err, "failed to decode boolean element at 0: boolean value must not be null")
+	assertError(t, err, "failed to decode field /0: boolean value must not be null")
 }
 
 func TestAsNullableBooleanSlice(t *testing.T) {
@@ -0,0 +1,417 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// unixMillisThreshold is the absolute Unix timestamp from which numbers are milliseconds instead of seconds.
+// 1e11 seconds is in the year 5138, while 1e11 milliseconds is in 1973.
+const unixMillisThreshold = 1e11
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+// DecodeNullableTime tries to convert an unknown value to a time pointer.
+// Strings are parsed with [ParseDateTime] or as Unix timestamps. Numbers are Unix timestamps in seconds,
+// or in milliseconds if the absolute value is at least 1e11.
+func DecodeNullableTime(value any) (*time.Time, error) {
+	return defaultDecoder.DecodeNullableTime(value)
+}
+
+// DecodeNullableTime tries to convert an unknown value to a time pointer with the configuration of the decoder.
+// See [DecodeNullableTime] for the supported values.
+func (d *Decoder) DecodeNullableTime(value any) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		return &v, nil
+	case *time.Time:
+		return v, nil
+	case Time:
+		return new(time.Time(v)), nil
+	case *Time:
+		if v == nil {
+			return nil, nil
+		}
+
+		return new(time.Time(*v)), nil
+	case string:
+		return d.parseTimeString(v)
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+
+		return d.parseTimeString(*v)
+	default:
+		return d.DecodeNullableTimeReflection(reflect.ValueOf(value))
+	}
+}
+
+// DecodeTime tries to convert an unknown value to a time value.
+// See [DecodeNullableTime] for the supported values.
+func DecodeTime(value any) (time.Time, error) {
+	return defaultDecoder.DecodeTime(value)
+}
+
+// DecodeTime tries to convert an unknown value to a time value with the configuration of the decoder.
+// See [DecodeNullableTime] for the supported values.
+func (d *Decoder) DecodeTime(value any) (time.Time, error) {
+	result, err := d.DecodeNullableTime(value)
+
+	return requireDecodedValue(result, err, ErrTimeNull)
+}
+
+// DecodeNullableTimeReflection decodes a nullable time from reflection value.
+func DecodeNullableTimeReflection(value reflect.Value) (*time.Time, error) {
+	return defaultDecoder.DecodeNullableTimeReflection(value)
+}
+
+// DecodeNullableTimeReflection decodes a nullable time from reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeNullableTimeReflection(value reflect.Value) (*time.Time, error) {
+	inferredValue, ok := UnwrapPointerFromReflectValue(value)
+	if !ok {
+		return nil, nil
+	}
+
+	kind := inferredValue.Kind()
+
+	switch kind {
+	case reflect.String:
+		return d.parseTimeString(inferredValue.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(unixIntToTime(inferredValue.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if inferredValue.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: %d is out of range", ErrMalformedTime, inferredValue.Uint())
+		}
+
+		return new(unixIntToTime(int64(inferredValue.Uint()))), nil
+	case reflect.Float32, reflect.Float64:
+		return unixFloatToTime(inferredValue.Float())
+	case reflect.Struct:
+		if inferredValue.Type().ConvertibleTo(timeType) {
+			return new(inferredValue.Convert(timeType).Interface().(time.Time)), nil //nolint:forcetypeassert
+		}
+	case reflect.Interface:
+		return d.DecodeNullableTime(inferredValue.Interface())
+	default:
+	}
+
+	return nil, fmt.Errorf("%w; got: %s", ErrMalformedTime, inferredValue.Type())
+}
+
+// DecodeTimeReflection decodes a time from reflection value.
+func DecodeTimeReflection(value reflect.Value) (time.Time, error) {
+	return defaultDecoder.DecodeTimeReflection(value)
+}
+
+// DecodeTimeReflection decodes a time from reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeTimeReflection(value reflect.Value) (time.Time, error) {
+	result, err := d.DecodeNullableTimeReflection(value)
+
+	return requireDecodedValue(result, err, ErrTimeNull)
+}
+
+// DecodeTimeSlice decodes a time slice from an unknown value.
+func DecodeTimeSlice(value any) ([]time.Time, error) {
+	return defaultDecoder.DecodeTimeSlice(value)
+}
+
+// DecodeTimeSlice decodes a time slice from an unknown value with the configuration of the decoder.
+func (d *Decoder) DecodeTimeSlice(value any) ([]time.Time, error) {
+	switch vs := value.(type) {
+	case nil:
+		return nil, nil
+	case []time.Time:
+		return vs, nil
+	default:
+		return d.DecodeTimeSliceReflection(reflect.ValueOf(value))
+	}
+}
+
+// DecodeTimeSliceReflection decodes a time slice from a reflection value.
+func DecodeTimeSliceReflection(reflectValue reflect.Value) ([]time.Time, error) {
+	return defaultDecoder.DecodeTimeSliceReflection(reflectValue)
+}
+
+// DecodeTimeSliceReflection decodes a time slice from a reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeTimeSliceReflection(reflectValue reflect.Value) ([]time.Time, error) {
+	return decodeSliceReflection(
+		reflectValue,
+		d.DecodeNullableTimeReflection,
+		ErrMalformedTimeSlice,
+		ErrTimeNull,
+	)
+}
+
+// DecodeNullableDuration tries to convert an unknown value to a duration pointer.
+// Strings are parsed with [ParseDuration], [time.ParseDuration] or as numbers of seconds,
+// e.g. 1d2h, 1.5h or 90. Numbers are seconds.
+func DecodeNullableDuration(value any) (*time.Duration, error) {
+	return defaultDecoder.DecodeNullableDuration(value)
+}
+
+// DecodeNullableDuration tries to convert an unknown value to a duration pointer with the configuration of the decoder.
+// See [DecodeNullableDuration] for the supported values.
+func (d *Decoder) DecodeNullableDuration(value any) (*time.Duration, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case time.Duration:
+		return &v, nil
+	case *time.Duration:
+		return v, nil
+	case Duration:
+		return new(time.Duration(v)), nil
+	case *Duration:
+		if v == nil {
+			return nil, nil
+		}
+
+		return new(time.Duration(*v)), nil
+	case string:
+		return d.parseDurationString(v)
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+
+		return d.parseDurationString(*v)
+	default:
+		return d.DecodeNullableDurationReflection(reflect.ValueOf(value))
+	}
+}
+
+// DecodeDuration tries to convert an unknown value to a duration value.
+// See [DecodeNullableDuration] for the supported values.
+func DecodeDuration(value any) (time.Duration, error) {
+	return defaultDecoder.DecodeDuration(value)
+}
+
+// DecodeDuration tries to convert an unknown value to a duration value with the configuration of the decoder.
+// See [DecodeNullableDuration] for the supported values.
+func (d *Decoder) DecodeDuration(value any) (time.Duration, error) {
+	result, err := d.DecodeNullableDuration(value)
+
+	return requireDecodedValue(result, err, ErrDurationNull)
+}
+
+// DecodeNullableDurationReflection decodes a nullable duration from reflection value.
+func DecodeNullableDurationReflection(value reflect.Value) (*time.Duration, error) {
+	return defaultDecoder.DecodeNullableDurationReflection(value)
+}
+
+// DecodeNullableDurationReflection decodes a nullable duration from reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeNullableDurationReflection(value reflect.Value) (*time.Duration, error) {
+	inferredValue, ok := UnwrapPointerFromReflectValue(value)
+	if !ok {
+		return nil, nil
+	}
+
+	valueType := inferredValue.Type()
+
+	// Durations are integers of nanoseconds, not seconds.
+	if valueType == durationType || valueType == reflect.TypeFor[Duration]() {
+		return new(time.Duration(inferredValue.Int())), nil
+	}
+
+	switch inferredValue.Kind() {
+	case reflect.String:
+		return d.parseDurationString(inferredValue.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return secondsToDuration(float64(inferredValue.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return secondsToDuration(float64(inferredValue.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return secondsToDuration(inferredValue.Float())
+	case reflect.Interface:
+		return d.DecodeNullableDuration(inferredValue.Interface())
+	default:
+		return nil, fmt.Errorf("%w; got: %s", ErrMalformedDuration, valueType)
+	}
+}
+
+// DecodeDurationReflection decodes a duration from reflection value.
+func DecodeDurationReflection(value reflect.Value) (time.Duration, error) {
+	return defaultDecoder.DecodeDurationReflection(value)
+}
+
+// DecodeDurationReflection decodes a duration from reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeDurationReflection(value reflect.Value) (time.Duration, error) {
+	result, err := d.DecodeNullableDurationReflection(value)
+
+	return requireDecodedValue(result, err, ErrDurationNull)
+}
+
+// DecodeDurationSlice decodes a duration slice from an unknown value.
+func DecodeDurationSlice(value any) ([]time.Duration, error) {
+	return defaultDecoder.DecodeDurationSlice(value)
+}
+
+// DecodeDurationSlice decodes a duration slice from an unknown value with the configuration of the decoder.
+func (d *Decoder) DecodeDurationSlice(value any) ([]time.Duration, error) {
+	switch vs := value.(type) {
+	case nil:
+		return nil, nil
+	case []time.Duration:
+		return vs, nil
+	default:
+		return d.DecodeDurationSliceReflection(reflect.ValueOf(value))
+	}
+}
+
+// DecodeDurationSliceReflection decodes a duration slice from a reflection value.
+func DecodeDurationSliceReflection(reflectValue reflect.Value) ([]time.Duration, error) {
+	return defaultDecoder.DecodeDurationSliceReflection(reflectValue)
+}
+
+// DecodeDurationSliceReflection decodes a duration slice from a reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeDurationSliceReflection(reflectValue reflect.Value) ([]time.Duration, error) {
+	return decodeSliceReflection(
+		reflectValue,
+		d.DecodeNullableDurationReflection,
+		ErrMalformedDurationSlice,
+		ErrDurationNull,
+	)
+}
+
+func (d *Decoder) parseTimeString(value string) (*time.Time, error) {
+	value, isNull := d.normalizeString(value)
+	if isNull {
+		return nil, nil
+	}
+
+	result, err := ParseDateTimeNative(value)
+	if err == nil {
+		return &result, nil
+	}
+
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return new(unixIntToTime(unix)), nil
+	}
+
+	if unix, err := strconv.ParseFloat(value, 64); err == nil {
+		return unixFloatToTime(unix)
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrMalformedTime, value)
+}
+
+func unixIntToTime(value int64) time.Time {
+	if value >= unixMillisThreshold || value <= -unixMillisThreshold {
+		return time.UnixMilli(value).UTC()
+	}
+
+	return time.Unix(value, 0).UTC()
+}
+
+func unixFloatToTime(value float64) (*time.Time, error) {
+	if math.Abs(value) >= unixMillisThreshold {
+		value /= 1000
+	}
+
+	seconds, fraction := math.Modf(value)
+
+	// The same range of seconds as integers, because 2^63 is the smallest float that doesn't fit in int64.
+	if math.IsNaN(seconds) || seconds >= math.MaxInt64 || seconds < math.MinInt64 {
+		return nil, fmt.Errorf("%w: %v is out of range", ErrMalformedTime, value)
+	}
+
+	return new(time.Unix(int64(seconds), int64(math.Round(fraction*float64(time.Second)))).UTC()), nil
+}
+
+func (d *Decoder) parseDurationString(value string) (*time.Duration, error) {
+	value, isNull := d.normalizeString(value)
+	if isNull {
+		return nil, nil
+	}
+
+	if result, err := ParseDuration(value); err == nil {
+		return new(time.Duration(result)), nil
+	}
+
+	if result, err := time.ParseDuration(value); err == nil {
+		return &result, nil
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return secondsToDuration(seconds)
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrMalformedDuration, value)
+}
+
+func secondsToDuration(seconds float64) (*time.Duration, error) {
+	nanoseconds := math.Round(seconds * float64(time.Second))
+
+	if math.IsNaN(nanoseconds) || nanoseconds >= math.MaxInt64 || nanoseconds < math.MinInt64 {
+		return nil, fmt.Errorf("%w: %v seconds is out of range", ErrMalformedDuration, seconds)
+	}
+
+	return new(time.Duration(nanoseconds)), nil
+}
+
+// requireDecodedValue dereferences the decoded value, or returns the null error if the value is nil.
+func requireDecodedValue[T any](result *T, err error, nullErr error) (T, error) {
+	var empty T
+
+	if err != nil {
+		return empty, err
+	}
+
+	if result == nil {
+		return empty, nullErr
+	}
+
+	return *result, nil
+}
+
+// decodeSliceReflection decodes every element of a slice or array with the decoder of nullable elements.
+func decodeSliceReflection[T any](
+	reflectValue reflect.Value,
+	decodeElem func(reflect.Value) (*T, error),
+	malformedErr error,
+	nullErr error,
+) ([]T, error) {
+	reflectValue, ok := UnwrapPointerFromReflectValue(reflectValue)
+	if !ok {
+		return nil, nil
+	}
+
+	valueKind := reflectValue.Kind()
+	if valueKind != reflect.Slice && valueKind != reflect.Array {
+		return nil, fmt.Errorf("%w; got: %s", malformedErr, valueKind)
+	}
+
+	return decodeElements(reflectValue.Len(), func(i int) (T, error) {
+		elem, err := decodeElem(reflectValue.Index(i))
+
+		return requireDecodedValue(elem, err, nullErr)
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeTime(t *testing.T) {
+	expected := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		Name     string
+		Value    any
+		Expected time.Time
+	}{
+		{Name: "time", Value: expected, Expected: expected},
+		{Name: "goutils_time", Value: new(Time(expected)), Expected: expected},
+		{Name: "rfc3339", Value: "2026-01-02T03:04:05Z", Expected: expected},
+		{Name: "date", Value: "2026-01-02", Expected: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Name: "unix_seconds", Value: expected.Unix(), Expected: expected},
+		{Name: "unix_millis", Value: uint64(expected.UnixMilli()) + 500, Expected: expected.Add(500 * time.Millisecond)},
+		{Name: "unix_float", Value: float64(expected.Unix()) + 0.25, Expected: expected.Add(250 * time.Millisecond)},
+		{Name: "unix_float_after_2262", Value: float64(1e10), Expected: time.Unix(1e10, 0).UTC()},
+		{Name: "unix_json_float_after_2262", Value: []any{99_999_999_999.5}[0], Expected: time.Unix(99_999_999_999, 5e8).UTC()},
+		{Name: "unix_string", Value: new("1767323045"), Expected: expected},
+		{Name: "json_number", Value: json.Number("1767323045000"), Expected: expected},
+		{Name: "any", Value: []any{"2026-01-02T03:04:05Z"}[0], Expected: expected},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := DecodeTime(tc.Value)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			if !result.Equal(tc.Expected) {
+				t.Fatalf("expected %s, got: %s", tc.Expected, result)
+			}
+		})
+	}
+
+	t.Run("null", func(t *testing.T) {
+		result, err := DecodeNullableTime((*string)(nil))
+		if err != nil || result != nil {
+			t.Fatalf("expected nil, got: %v, %v", result, err)
+		}
+
+		_, err = DecodeTime(nil)
+		if !errors.Is(err, ErrTimeNull) {
+			t.Fatalf("expected ErrTimeNull, got: %v", err)
+		}
+
+		_, err = DecodeTimeReflection(reflect.ValueOf((*time.Time)(nil)))
+		if !errors.Is(err, ErrTimeNull) {
+			t.Fatalf("expected ErrTimeNull, got: %v", err)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, value := range []any{"yesterday", " 2026-01-02 ", true, math.Inf(1), 1e22, uint64(math.MaxUint64), []int{1}} {
+			_, err := DecodeTime(value)
+			if !errors.Is(err, ErrMalformedTime) {
+				t.Errorf("expected ErrMalformedTime of %v, got: %v", value, err)
+			}
+		}
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		result, err := DecodeTimeSlice([]any{"2026-01-02T03:04:05Z", expected.Unix()})
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if len(result) != 2 || !result[0].Equal(expected) || !result[1].Equal(expected) {
+			t.Fatalf("unexpected result: %v", result)
+		}
+
+		_, err = DecodeTimeSlice([]any{"2026-01-02T03:04:05Z", nil})
+		if !errors.Is(err, ErrTimeNull) {
+			t.Fatalf("expected ErrTimeNull, got: %v", err)
+		}
+
+		_, err = DecodeTimeSlice("2026-01-02T03:04:05Z")
+		if !errors.Is(err, ErrMalformedTimeSlice) {
+			t.Fatalf("expected ErrMalformedTimeSlice, got: %v", err)
+		}
+	})
+}
+
+func TestDecodeDuration(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Value    any
+		Expected time.Duration
+	}{
+		{Name: "duration", Value: time.Minute, Expected: time.Minute},
+		{Name: "goutils_duration", Value: new(Duration(time.Hour)), Expected: time.Hour},
+		{Name: "goutils_syntax", Value: "1d2h", Expected: 26 * time.Hour},
+		{Name: "go_syntax", Value: "1.5h", Expected: 90 * time.Minute},
+		{Name: "negative", Value: "-30s", Expected: -30 * time.Second},
+		{Name: "seconds_string", Value: new("1.5"), Expected: 1500 * time.Millisecond},
+		{Name: "seconds", Value: 90, Expected: 90 * time.Second},
+		{Name: "float_seconds", Value: float32(0.25), Expected: 250 * time.Millisecond},
+		{Name: "json_number", Value: json.Number("2"), Expected: 2 * time.Second},
+		{Name: "any", Value: []any{uint8(3)}[0], Expected: 3 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := DecodeDuration(tc.Value)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			assertEqual(t, tc.Expected, result)
+		})
+	}
+
+	t.Run("null", func(t *testing.T) {
+		result, err := DecodeNullableDuration(nil)
+		if err != nil || result != nil {
+			t.Fatalf("expected nil, got: %v, %v", result, err)
+		}
+
+		_, err = DecodeDurationReflection(reflect.ValueOf((*Duration)(nil)))
+		if !errors.Is(err, ErrDurationNull) {
+			t.Fatalf("expected ErrDurationNull, got: %v", err)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, value := range []any{"1x", "", " 1.5 ", false, 1e10, math.NaN(), map[string]any{}} {
+			_, err := DecodeDuration(value)
+			if !errors.Is(err, ErrMalformedDuration) {
+				t.Errorf("expected ErrMalformedDuration of %v, got: %v", value, err)
+			}
+		}
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		result, err := DecodeDurationSlice([]string{"1s", "2"})
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		assertDeepEqual(t, []time.Duration{time.Second, 2 * time.Second}, result)
+
+		_, err = DecodeDurationSlice([]any{"1s", "x"})
+		if !errors.Is(err, ErrMalformedDuration) {
+			t.Fatalf("expected ErrMalformedDuration, got: %v", err)
+		}
+
+		_, err = DecodeDurationSliceReflection(reflect.ValueOf(1))
+		if !errors.Is(err, ErrMalformedDurationSlice) {
+			t.Fatalf("expected ErrMalformedDurationSlice, got: %v", err)
+		}
+	})
+}
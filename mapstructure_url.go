@@ -0,0 +1,174 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+)
+
+var urlType = reflect.TypeFor[url.URL]()
+
+// DecodeNullableURL tries to convert an unknown value to a URL pointer.
+// Strings are parsed with [ParsePathOrHTTPURL], so they are either paths or HTTP(S) URLs.
+func DecodeNullableURL(value any) (*url.URL, error) {
+	return defaultDecoder.DecodeNullableURL(value)
+}
+
+// DecodeNullableURL tries to convert an unknown value to a URL pointer with the configuration of the decoder.
+// See [DecodeNullableURL] for the supported values.
+func (d *Decoder) DecodeNullableURL(value any) (*url.URL, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case *url.URL:
+		return v, nil
+	case url.URL:
+		return &v, nil
+	case string:
+		return d.parseURLString(v)
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+
+		return d.parseURLString(*v)
+	default:
+		return d.DecodeNullableURLReflection(reflect.ValueOf(value))
+	}
+}
+
+// DecodeURL tries to convert an unknown value to a URL.
+// See [DecodeNullableURL] for the supported values.
+func DecodeURL(value any) (*url.URL, error) {
+	return defaultDecoder.DecodeURL(value)
+}
+
+// DecodeURL tries to convert an unknown value to a URL with the configuration of the decoder.
+// See [DecodeNullableURL] for the supported values.
+func (d *Decoder) DecodeURL(value any) (*url.URL, error) {
+	result, err := d.DecodeNullableURL(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, ErrURLNull
+	}
+
+	return result, nil
+}
+
+// DecodeNullableURLReflection decodes a nullable URL from reflection value.
+func DecodeNullableURLReflection(value reflect.Value) (*url.URL, error) {
+	return defaultDecoder.DecodeNullableURLReflection(value)
+}
+
+// DecodeNullableURLReflection decodes a nullable URL from reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeNullableURLReflection(value reflect.Value) (*url.URL, error) {
+	inferredValue, ok := UnwrapPointerFromReflectValue(value)
+	if !ok {
+		return nil, nil
+	}
+
+	switch inferredValue.Kind() {
+	case reflect.String:
+		return d.parseURLString(inferredValue.String())
+	case reflect.Struct:
+		if inferredValue.Type() == urlType {
+			return new(inferredValue.Interface().(url.URL)), nil //nolint:forcetypeassert
+		}
+	case reflect.Interface:
+		return d.DecodeNullableURL(inferredValue.Interface())
+	default:
+	}
+
+	return nil, fmt.Errorf("%w; got: %s", ErrMalformedURL, inferredValue.Type())
+}
+
+// DecodeURLReflection decodes a URL from reflection value.
+func DecodeURLReflection(value reflect.Value) (*url.URL, error) {
+	return defaultDecoder.DecodeURLReflection(value)
+}
+
+// DecodeURLReflection decodes a URL from reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeURLReflection(value reflect.Value) (*url.URL, error) {
+	result, err := d.DecodeNullableURLReflection(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, ErrURLNull
+	}
+
+	return result, nil
+}
+
+// DecodeURLSlice decodes a URL slice from an unknown value.
+func DecodeURLSlice(value any) ([]*url.URL, error) {
+	return defaultDecoder.DecodeURLSlice(value)
+}
+
+// DecodeURLSlice decodes a URL slice from an unknown value with the configuration of the decoder.
+func (d *Decoder) DecodeURLSlice(value any) ([]*url.URL, error) {
+	switch vs := value.(type) {
+	case nil:
+		return nil, nil
+	case []*url.URL:
+		return vs, nil
+	default:
+		return d.DecodeURLSliceReflection(reflect.ValueOf(value))
+	}
+}
+
+// DecodeURLSliceReflection decodes a URL slice from a reflection value.
+func DecodeURLSliceReflection(reflectValue reflect.Value) ([]*url.URL, error) {
+	return defaultDecoder.DecodeURLSliceReflection(reflectValue)
+}
+
+// DecodeURLSliceReflection decodes a URL slice from a reflection value with the configuration of the decoder.
+func (d *Decoder) DecodeURLSliceReflection(reflectValue reflect.Value) ([]*url.URL, error) {
+	return decodeSliceReflection(
+		reflectValue,
+		func(value reflect.Value) (**url.URL, error) {
+			result, err := d.DecodeNullableURLReflection(value)
+			if result == nil {
+				return nil, err
+			}
+
+			return &result, err
+		},
+		ErrMalformedURLSlice,
+		ErrURLNull,
+	)
+}
+
+func (d *Decoder) parseURLString(value string) (*url.URL, error) {
+	value, isNull := d.normalizeString(value)
+	if isNull {
+		return nil, nil
+	}
+
+	result, err := ParsePathOrHTTPURL(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedURL, err)
+	}
+
+	return result, nil
+}
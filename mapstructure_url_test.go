@@ -0,0 +1,120 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeURL(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Value    any
+		Expected string
+	}{
+		{Name: "string", Value: "https://example.com/api?a=1", Expected: "https://example.com/api?a=1"},
+		{Name: "path", Value: new("/api/v1"), Expected: "/api/v1"},
+		{Name: "url", Value: url.URL{Scheme: "http", Host: "localhost"}, Expected: "http://localhost"},
+		{Name: "any", Value: []any{"http://localhost:8080"}[0], Expected: "http://localhost:8080"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			result, err := DecodeURL(tc.Value)
+			if err != nil {
+				t.Fatalf("expected nil error, got: %s", err)
+			}
+
+			assertEqual(t, tc.Expected, result.String())
+		})
+	}
+
+	t.Run("null", func(t *testing.T) {
+		result, err := DecodeNullableURL((*url.URL)(nil))
+		if err != nil || result != nil {
+			t.Fatalf("expected nil, got: %v, %v", result, err)
+		}
+
+		_, err = DecodeURLReflection(reflect.ValueOf((*string)(nil)))
+		if !errors.Is(err, ErrURLNull) {
+			t.Fatalf("expected ErrURLNull, got: %v", err)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, value := range []any{"ftp://example.com", "://example.com", 1, []string{"/"}} {
+			_, err := DecodeURL(value)
+			if !errors.Is(err, ErrMalformedURL) {
+				t.Errorf("expected ErrMalformedURL of %v, got: %v", value, err)
+			}
+		}
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		result, err := DecodeURLSlice([]any{"/a", "https://example.com"})
+		if err != nil {
+			t.Fatalf("expected nil error, got: %s", err)
+		}
+
+		if len(result) != 2 || result[0].Path != "/a" || result[1].Host != "example.com" {
+			t.Fatalf("unexpected result: %v", result)
+		}
+
+		_, err = DecodeURLSlice([]any{"/a", nil})
+		if !errors.Is(err, ErrURLNull) {
+			t.Fatalf("expected ErrURLNull, got: %v", err)
+		}
+
+		_, err = DecodeURLSlice("/a")
+		if !errors.Is(err, ErrMalformedURLSlice) {
+			t.Fatalf("expected ErrMalformedURLSlice, got: %v", err)
+		}
+	})
+}
+
+func TestDecodeStruct_KnownTypes(t *testing.T) {
+	type config struct {
+		StartsAt time.Time     `json:"startsAt"`
+		Timeout  time.Duration `json:"timeout"`
+		Endpoint url.URL       `json:"endpoint"`
+	}
+
+	result, err := DecodeStruct[config](map[string]any{
+		"startsAt": 1767323045,
+		"timeout":  "1.5s",
+		"endpoint": "https://example.com",
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	if !result.StartsAt.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) || result.Timeout != 1500*time.Millisecond ||
+		result.Endpoint.Host != "example.com" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	_, err = DecodeStruct[config](map[string]any{"timeout": true, "endpoint": "ftp://example.com"})
+
+	var decodeErrs DecodeFieldErrors
+
+	if !errors.As(err, &decodeErrs) || len(decodeErrs) != 2 || !errors.Is(decodeErrs[0], ErrMalformedDuration) ||
+		!errors.Is(decodeErrs[1], ErrMalformedURL) {
+		t.Fatalf("expected duration and URL errors, got: %v", err)
+	}
+}
@@ -18,9 +18,12 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"slices"
 	"strings"
+	"syscall"
+	"time"
 )
 
 var (
@@ -255,6 +258,83 @@ func ValidateIP(ip net.IP, options ValidateIPOptions) error {
 	return ErrBlockedIP
 }
 
+// NewSafeDialControl creates a [net.Dialer] Control hook for SSRF protection.
+// The hook is called with the resolved IP address right before the socket connects,
+// so the validation can't be bypassed by DNS rebinding between validation and connection.
+// The LookupIP function of the options is ignored because the address is already resolved.
+func NewSafeDialControl(
+	options ValidateIPOptions,
+) func(network string, address string, conn syscall.RawConn) error {
+	return func(_ string, address string, _ syscall.RawConn) error {
+		return validateDialAddress(address, options)
+	}
+}
+
+// NewSafeDialContext creates a dial function that validates the exact IP address
+// being connected to with the SSRF options. The base dialer is copied and isn't modified.
+// Its Control hook, if any, is still called after the validation passes.
+func NewSafeDialContext(
+	dialer *net.Dialer,
+	options ValidateIPOptions,
+) func(ctx context.Context, network string, address string) (net.Conn, error) {
+	var safeDialer net.Dialer
+
+	if dialer != nil {
+		safeDialer = *dialer
+	}
+
+	control := safeDialer.Control
+	controlContext := safeDialer.ControlContext
+
+	safeDialer.Control = nil
+	safeDialer.ControlContext = func(
+		ctx context.Context,
+		network string,
+		address string,
+		conn syscall.RawConn,
+	) error {
+		err := validateDialAddress(address, options)
+		if err != nil {
+			return err
+		}
+
+		if controlContext != nil {
+			return controlContext(ctx, network, address, conn)
+		}
+
+		if control != nil {
+			return control(network, address, conn)
+		}
+
+		return nil
+	}
+
+	return safeDialer.DialContext
+}
+
+// NewSafeHTTPTransport creates an HTTP transport that validates every connection,
+// including ones opened for redirects, with the SSRF options.
+// The transport is cloned from [http.DefaultTransport] with proxies disabled,
+// because the dialer would validate the proxy address instead of the target.
+func NewSafeHTTPTransport(options ValidateIPOptions) *http.Transport {
+	var transport *http.Transport
+
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if ok {
+		transport = defaultTransport.Clone()
+	} else {
+		transport = &http.Transport{}
+	}
+
+	transport.Proxy = nil
+	transport.DialContext = NewSafeDialContext(&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}, options)
+
+	return transport
+}
+
 // ParseSubnet parses the subnet from a raw string.
 func ParseSubnet(value string) (*net.IPNet, error) {
 	if value == "" {
@@ -297,6 +377,25 @@ func parseIPRanges(ipRanges []string) ([]*net.IPNet, error) {
 	return results, nil
 }
 
+func validateDialAddress(address string, options ValidateIPOptions) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%w: %s is not an IP address", ErrBlockedIP, host)
+	}
+
+	err = ValidateIP(ip, options)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, ip)
+	}
+
+	return nil
+}
+
 func validateHost(host, hostname string, options *ValidateHTTPURLOptions) error {
 	for _, expr := range options.BlockedHosts {
 		re, err := NewRegexpMatcher(expr)
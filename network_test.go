@@ -19,9 +19,11 @@ import (
 	"errors"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"strings"
+	"syscall"
 	"testing"
 )
 
@@ -582,6 +584,143 @@ func TestParseSubnet(t *testing.T) {
 	})
 }
 
+func TestNewSafeHTTPTransport(t *testing.T) {
+	_, loopback, _ := parseNetCIDR("127.0.0.1/32")
+
+	t.Run("blocks private IP at connect time", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: NewSafeHTTPTransport(ValidateIPOptions{PublicIPOnly: true}),
+		}
+
+		// The hostname is resolved by the transport, not by a validation step beforehand.
+		serverURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+
+		resp, err := client.Get(serverURL)
+		if err == nil {
+			CloseResponse(resp)
+			t.Fatal("expected connection error, got nil")
+		}
+
+		if !errors.Is(err, ErrBlockedIP) {
+			t.Fatalf("expected ErrBlockedIP, got: %v", err)
+		}
+	})
+
+	t.Run("allows IP in allowed range", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: NewSafeHTTPTransport(ValidateIPOptions{
+				PublicIPOnly:    true,
+				AllowedIPRanges: []*net.IPNet{loopback},
+			}),
+		}
+
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("expected nil error, got: %v", err)
+		}
+
+		CloseResponse(resp)
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200, got: %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("validates redirect hops", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.2:0")
+		if err != nil {
+			t.Skipf("127.0.0.2 is not available: %s", err)
+		}
+
+		internalServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		internalServer.Listener = listener
+		internalServer.Start()
+		defer internalServer.Close()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, internalServer.URL, http.StatusFound)
+		}))
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: NewSafeHTTPTransport(ValidateIPOptions{
+				AllowedIPRanges: []*net.IPNet{loopback},
+			}),
+		}
+
+		resp, err := client.Get(server.URL)
+		if err == nil {
+			CloseResponse(resp)
+			t.Fatal("expected redirect to be blocked, got nil")
+		}
+
+		if !errors.Is(err, ErrBlockedIP) {
+			t.Fatalf("expected ErrBlockedIP, got: %v", err)
+		}
+	})
+}
+
+func TestNewSafeDialContext(t *testing.T) {
+	_, blocked, _ := parseNetCIDR("127.0.0.0/8")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	controlCalled := false
+	dialer := &net.Dialer{
+		Control: func(network, address string, conn syscall.RawConn) error {
+			controlCalled = true
+
+			return nil
+		},
+	}
+
+	_, err = NewSafeDialContext(dialer, ValidateIPOptions{
+		BlockedIPRanges: []*net.IPNet{blocked},
+	})(context.Background(), "tcp", listener.Addr().String())
+	if !errors.Is(err, ErrBlockedIP) {
+		t.Fatalf("expected ErrBlockedIP, got: %v", err)
+	}
+
+	if controlCalled {
+		t.Fatal("expected the base control hook not to be called for a blocked IP")
+	}
+
+	conn, err := NewSafeDialContext(dialer, ValidateIPOptions{})(
+		context.Background(),
+		"tcp",
+		listener.Addr().String(),
+	)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+
+	_ = conn.Close()
+
+	if !controlCalled {
+		t.Fatal("expected the base control hook to be called")
+	}
+
+	if dialer.ControlContext != nil {
+		t.Fatal("expected the base dialer not to be modified")
+	}
+}
+
 // parseNetCIDR is a helper that wraps net.ParseCIDR for test use.
 func parseNetCIDR(s string) (net.IP, *net.IPNet, error) {
 	return net.ParseCIDR(s)
@@ -0,0 +1,426 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultWatchInterval       = time.Second
+	defaultWatchRemoteInterval = time.Minute
+)
+
+// WatcherValidator abstracts a watched document that can validate itself.
+type WatcherValidator interface {
+	Validate() error
+}
+
+// Watcher loads a JSON or YAML document with the same rules as [ReadJSONOrYAMLFile]
+// and reloads it when the content changes.
+//
+// Local files are polled on the modification time and size, and only decoded if the hash of
+// the content changed. Remote URLs and other file sources are fetched on an interval.
+// Combine it with [DownloadFileWithCache] to make remote polling cheap.
+//
+// If a reload fails to read, decode or validate, the last good value is kept
+// and the error is reported to the error handler.
+//
+// Only the document itself is watched. Files pulled in with [DownloadFileWithIncludes] aren't checked
+// for changes, so changes of included files are picked up the next time the document changes.
+type Watcher[T any] struct {
+	filePath        string
+	localPath       string
+	options         *watcherOptions
+	downloadOptions *downloadFileOptions
+	validator       func(value *T) error
+
+	current atomic.Pointer[T]
+
+	// reloadLock guards the state of the last read content.
+	reloadLock sync.Mutex
+	modTime    time.Time
+	size       int64
+	hash       [sha256.Size]byte
+
+	subscribersLock  sync.RWMutex
+	subscribers      []watcherSubscriber[T]
+	nextSubscriberID uint64
+
+	// notifyLock guards the changes that are waiting to be sent to subscribers.
+	notifyLock     sync.Mutex
+	pendingChanges []watcherChange[T]
+	notifying      bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type watcherSubscriber[T any] struct {
+	id       uint64
+	callback func(oldValue, newValue *T)
+}
+
+type watcherChange[T any] struct {
+	oldValue *T
+	newValue *T
+}
+
+// NewWatcher loads the document and starts watching it in the background
+// until the context is canceled or the watcher is closed.
+// It returns an error if the initial load fails.
+//
+// The document is rejected if the validator returns an error. The validator may be nil.
+// If the document implements the [WatcherValidator] interface, its Validate method is called first.
+func NewWatcher[T any](
+	ctx context.Context,
+	filePath string,
+	validator func(value *T) error,
+	options ...WatcherOption,
+) (*Watcher[T], error) {
+	opts := &watcherOptions{
+		Interval:       defaultWatchInterval,
+		RemoteInterval: defaultWatchRemoteInterval,
+	}
+
+	for _, opt := range options {
+		if opt == nil {
+			continue
+		}
+
+		opt(opts)
+	}
+
+	watcher := &Watcher[T]{
+		filePath:        filePath,
+		options:         opts,
+		downloadOptions: newDownloadFileOptions(opts.DownloadOptions),
+		validator:       validator,
+		done:            make(chan struct{}),
+	}
+
+	watcher.localPath = watcher.resolveLocalPath()
+
+	_, err := watcher.Reload(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := opts.RemoteInterval
+	if watcher.localPath != "" {
+		interval = opts.Interval
+	}
+
+	ctx, watcher.cancel = context.WithCancel(ctx)
+
+	go watcher.watch(ctx, interval)
+
+	return watcher, nil
+}
+
+// Load returns the current value. The returned value must not be modified.
+func (w *Watcher[T]) Load() *T {
+	return w.current.Load()
+}
+
+// Subscribe registers a callback that is called with the old and new values after every
+// successful reload. Callbacks are called in the order of registration and the changes are sent in order,
+// outside of the reload lock, so a callback may call [Watcher.Reload]. A callback must not call [Watcher.Close],
+// which waits for the background goroutine that may be calling the callback.
+// It returns a function to unsubscribe the callback.
+func (w *Watcher[T]) Subscribe(callback func(oldValue, newValue *T)) func() {
+	w.subscribersLock.Lock()
+	defer w.subscribersLock.Unlock()
+
+	w.nextSubscriberID++
+	id := w.nextSubscriberID
+
+	w.subscribers = append(w.subscribers, watcherSubscriber[T]{
+		id:       id,
+		callback: callback,
+	})
+
+	return func() {
+		w.subscribersLock.Lock()
+		defer w.subscribersLock.Unlock()
+
+		w.subscribers = slices.DeleteFunc(w.subscribers, func(item watcherSubscriber[T]) bool {
+			return item.id == id
+		})
+	}
+}
+
+// Reload reads the document immediately and replaces the current value if the content changed.
+// It returns true if the value was replaced.
+func (w *Watcher[T]) Reload(ctx context.Context) (bool, error) {
+	changed, err := w.reload(ctx)
+	if changed {
+		w.dispatch()
+	}
+
+	return changed, err
+}
+
+func (w *Watcher[T]) reload(ctx context.Context) (bool, error) {
+	w.reloadLock.Lock()
+	defer w.reloadLock.Unlock()
+
+	hasValue := w.current.Load() != nil
+
+	var info os.FileInfo
+
+	if w.localPath != "" {
+		var err error
+
+		info, err = os.Stat(w.localPath)
+		if err != nil {
+			return false, err
+		}
+
+		if hasValue && info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+			return false, nil
+		}
+	}
+
+	content, ext, err := w.readContent(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if info != nil {
+		w.modTime = info.ModTime()
+		w.size = info.Size()
+	}
+
+	hash := sha256.Sum256(content)
+	if hasValue && hash == w.hash {
+		return false, nil
+	}
+
+	// Remember the hash before decoding, so an invalid content is reported once until it changes.
+	w.hash = hash
+
+	value, err := decodeJSONOrYAMLFile[T](ctx, bytes.NewReader(content), w.filePath, ext, w.downloadOptions)
+	if err != nil {
+		return false, err
+	}
+
+	err = w.validate(value)
+	if err != nil {
+		return false, err
+	}
+
+	oldValue := w.current.Swap(value)
+
+	if hasValue {
+		w.notifyLock.Lock()
+		w.pendingChanges = append(w.pendingChanges, watcherChange[T]{oldValue: oldValue, newValue: value})
+		w.notifyLock.Unlock()
+	}
+
+	return true, nil
+}
+
+// Close stops watching the document and waits until the background goroutine exits.
+// It must not be called from a subscriber or the error handler.
+func (w *Watcher[T]) Close() error {
+	w.cancel()
+	<-w.done
+
+	return nil
+}
+
+func (w *Watcher[T]) watch(ctx context.Context, interval time.Duration) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := w.Reload(ctx)
+			if err != nil && ctx.Err() == nil {
+				w.handleError(fmt.Errorf("failed to reload %s: %w", w.filePath, err))
+			}
+		}
+	}
+}
+
+func (w *Watcher[T]) readContent(ctx context.Context) ([]byte, string, error) {
+	reader, ext, err := fileReaderFromPath(ctx, w.filePath, w.downloadOptions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer CatchWarnErrorFunc(reader.Close)
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return content, ext, nil
+}
+
+func (w *Watcher[T]) validate(value *T) error {
+	if validator, ok := any(value).(WatcherValidator); ok {
+		err := validator.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if w.validator != nil {
+		return w.validator(value)
+	}
+
+	return nil
+}
+
+// dispatch sends the pending changes to subscribers in order. Only one goroutine sends changes at a time,
+// so changes queued by a reload during a callback are sent after the callback returns.
+func (w *Watcher[T]) dispatch() {
+	w.notifyLock.Lock()
+
+	if w.notifying {
+		w.notifyLock.Unlock()
+
+		return
+	}
+
+	w.notifying = true
+
+	for len(w.pendingChanges) > 0 {
+		change := w.pendingChanges[0]
+		w.pendingChanges = w.pendingChanges[1:]
+
+		w.notifyLock.Unlock()
+		w.notify(change.oldValue, change.newValue)
+		w.notifyLock.Lock()
+	}
+
+	w.notifying = false
+	w.notifyLock.Unlock()
+}
+
+func (w *Watcher[T]) notify(oldValue, newValue *T) {
+	w.subscribersLock.RLock()
+	subscribers := slices.Clone(w.subscribers)
+	w.subscribersLock.RUnlock()
+
+	for _, subscriber := range subscribers {
+		subscriber.callback(oldValue, newValue)
+	}
+}
+
+func (w *Watcher[T]) handleError(err error) {
+	if w.options.ErrorHandler != nil {
+		w.options.ErrorHandler(err)
+
+		return
+	}
+
+	slog.Warn(err.Error())
+}
+
+// resolveLocalPath returns the filesystem path of the document,
+// or an empty string if it's a remote URL or a custom file source.
+func (w *Watcher[T]) resolveLocalPath() string {
+	filePath := strings.TrimSpace(w.filePath)
+
+	fileURL, source, err := lookupFileSource(filePath, w.downloadOptions)
+	if err != nil {
+		return ""
+	}
+
+	if source != nil {
+		if fileURL.Scheme != fileURLScheme || w.downloadOptions.FileSources[fileURLScheme] != nil {
+			return ""
+		}
+
+		localPath := fileURL.Path
+		if fileURL.Opaque != "" {
+			localPath = fileURL.Opaque
+		}
+
+		return filepath.Clean(filepath.FromSlash(localPath))
+	}
+
+	parsedURL, err := ParsePathOrURL(filePath)
+	if err != nil || parsedURL.Scheme != "" {
+		return ""
+	}
+
+	return filepath.Clean(filePath)
+}
+
+// WatcherOption abstracts a function to modify watcher options.
+type WatcherOption func(opts *watcherOptions)
+
+type watcherOptions struct {
+	Interval        time.Duration
+	RemoteInterval  time.Duration
+	DownloadOptions []DownloadFileOption
+	ErrorHandler    func(err error)
+}
+
+// WatchWithInterval creates an option to set the polling interval of local files. The default is 1 second.
+func WatchWithInterval(interval time.Duration) WatcherOption {
+	return func(opts *watcherOptions) {
+		if interval > 0 {
+			opts.Interval = interval
+		}
+	}
+}
+
+// WatchWithRemoteInterval creates an option to set the polling interval of remote URLs
+// and custom file sources. The default is 1 minute.
+func WatchWithRemoteInterval(interval time.Duration) WatcherOption {
+	return func(opts *watcherOptions) {
+		if interval > 0 {
+			opts.RemoteInterval = interval
+		}
+	}
+}
+
+// WatchWithDownloadOptions creates an option to set the options to read the document.
+func WatchWithDownloadOptions(options ...DownloadFileOption) WatcherOption {
+	return func(opts *watcherOptions) {
+		opts.DownloadOptions = append(opts.DownloadOptions, options...)
+	}
+}
+
+// WatchWithErrorHandler creates an option to handle errors of background reloads.
+// Errors are logged with the WARN level by default.
+func WatchWithErrorHandler(handler func(err error)) WatcherOption {
+	return func(opts *watcherOptions) {
+		opts.ErrorHandler = handler
+	}
+}
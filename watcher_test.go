@@ -0,0 +1,282 @@
+// Copyright 2026 RelyChan Pte. Ltd
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goutils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type watcherTestConfig struct {
+	Name string `json:"name" yaml:"name"`
+	Port int    `json:"port" yaml:"port"`
+}
+
+var errWatcherTestInvalidPort = errors.New("port must be positive")
+
+func (c watcherTestConfig) Validate() error {
+	if c.Port <= 0 {
+		return errWatcherTestInvalidPort
+	}
+
+	return nil
+}
+
+func TestWatcher_LocalFile(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.yaml")
+	writeWatcherTestFile(t, filePath, "name: foo\nport: 8080\n", time.Now().Add(-time.Hour))
+
+	errs := make(chan error, 10)
+
+	watcher, err := NewWatcher[watcherTestConfig](
+		context.Background(),
+		filePath,
+		nil,
+		WatchWithInterval(10*time.Millisecond),
+		WatchWithErrorHandler(func(err error) {
+			errs <- err
+		}),
+	)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	defer watcher.Close() //nolint:errcheck
+
+	if watcher.Load().Name != "foo" {
+		t.Fatalf("expected foo, got: %v", watcher.Load())
+	}
+
+	changes := make(chan [2]watcherTestConfig, 10)
+
+	watcher.Subscribe(func(oldValue, newValue *watcherTestConfig) {
+		changes <- [2]watcherTestConfig{*oldValue, *newValue}
+	})
+
+	t.Run("reload_on_change", func(t *testing.T) {
+		writeWatcherTestFile(t, filePath, "name: bar\nport: 8080\n", time.Now())
+
+		select {
+		case change := <-changes:
+			if change[0].Name != "foo" || change[1].Name != "bar" {
+				t.Fatalf("unexpected change: %v", change)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the reload")
+		}
+
+		if watcher.Load().Name != "bar" {
+			t.Fatalf("expected bar, got: %v", watcher.Load())
+		}
+	})
+
+	t.Run("touch_without_change", func(t *testing.T) {
+		writeWatcherTestFile(t, filePath, "name: bar\nport: 8080\n", time.Now().Add(time.Minute))
+
+		changed, err := watcher.Reload(context.Background())
+		if err != nil || changed {
+			t.Fatalf("expected no change, got: %t, %v", changed, err)
+		}
+	})
+
+	t.Run("keep_last_good_value", func(t *testing.T) {
+		for _, content := range []string{"name: [invalid", "name: baz\nport: 0\n"} {
+			writeWatcherTestFile(t, filePath, content, time.Now().Add(2*time.Minute))
+
+			select {
+			case err := <-errs:
+				if err == nil {
+					t.Fatal("expected reload error, got nil")
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the reload error")
+			}
+
+			if watcher.Load().Name != "bar" {
+				t.Fatalf("expected the last good value, got: %v", watcher.Load())
+			}
+		}
+
+		if len(changes) > 0 {
+			t.Fatalf("expected no change notification, got: %v", <-changes)
+		}
+	})
+}
+
+func TestWatcher_Remote(t *testing.T) {
+	var version atomic.Int32
+
+	version.Store(1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if version.Load() == 1 {
+			_, _ = w.Write([]byte(`{"name": "v1", "port": 1}`))
+		} else {
+			_, _ = w.Write([]byte(`{"name": "v2", "port": 2}`))
+		}
+	}))
+	defer server.Close()
+
+	var validated atomic.Int32
+
+	watcher, err := NewWatcher[watcherTestConfig](
+		context.Background(),
+		server.URL+"/config",
+		func(value *watcherTestConfig) error {
+			validated.Add(1)
+
+			return nil
+		},
+		WatchWithRemoteInterval(10*time.Millisecond),
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	defer watcher.Close() //nolint:errcheck
+
+	changes := make(chan string, 10)
+
+	unsubscribe := watcher.Subscribe(func(_, newValue *watcherTestConfig) {
+		changes <- newValue.Name
+	})
+
+	version.Store(2)
+
+	select {
+	case name := <-changes:
+		if name != "v2" {
+			t.Fatalf("expected v2, got: %s", name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the reload")
+	}
+
+	if validated.Load() != 2 {
+		t.Errorf("expected 2 validations, got: %d", validated.Load())
+	}
+
+	unsubscribe()
+	version.Store(1)
+
+	changed, err := watcher.Reload(context.Background())
+	if err != nil || !changed {
+		t.Fatalf("expected a change, got: %t, %v", changed, err)
+	}
+
+	if len(changes) > 0 {
+		t.Fatalf("expected no notification after unsubscribe, got: %s", <-changes)
+	}
+}
+
+func TestWatcher_ReloadFromSubscriber(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.yaml")
+	writeWatcherTestFile(t, filePath, "name: foo\nport: 8080\n", time.Now().Add(-time.Hour))
+
+	watcher, err := NewWatcher[watcherTestConfig](context.Background(), filePath, nil, WatchWithInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("expected nil error, got: %s", err)
+	}
+
+	defer watcher.Close() //nolint:errcheck
+
+	var changes []string
+
+	watcher.Subscribe(func(oldValue, newValue *watcherTestConfig) {
+		changes = append(changes, oldValue.Name+"->"+newValue.Name)
+
+		if newValue.Name == "bar" {
+			writeWatcherTestFile(t, filePath, "name: baz\nport: 8080\n", time.Now().Add(time.Minute))
+
+			// The change is sent after this callback returns.
+			changed, err := watcher.Reload(context.Background())
+			if err != nil || !changed || len(changes) != 1 {
+				t.Errorf("expected the nested reload to be queued, got: %t, %v, %v", changed, err, changes)
+			}
+		}
+	})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		writeWatcherTestFile(t, filePath, "name: bar\nport: 8080\n", time.Now())
+
+		_, err := watcher.Reload(context.Background())
+		if err != nil {
+			t.Errorf("expected nil error, got: %s", err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("deadlock when a subscriber reloads the watcher")
+	}
+
+	if len(changes) != 2 || changes[0] != "foo->bar" || changes[1] != "bar->baz" {
+		t.Fatalf("expected changes in order, got: %v", changes)
+	}
+}
+
+func TestWatcher_InitialLoadFailure(t *testing.T) {
+	_, err := NewWatcher[watcherTestConfig](context.Background(), "testdata/not-found.yaml", nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected os.ErrNotExist, got: %v", err)
+	}
+
+	_, err = NewWatcher[watcherTestConfig](
+		context.Background(),
+		`data:application/json,{"port": 0}`,
+		nil,
+	)
+	if !errors.Is(err, errWatcherTestInvalidPort) {
+		t.Fatalf("expected errWatcherTestInvalidPort, got: %v", err)
+	}
+
+	_, err = NewWatcher[watcherTestConfig](
+		context.Background(),
+		`data:application/json,{"port": 1}`,
+		func(value *watcherTestConfig) error { return errWatcherTestInvalidPort },
+	)
+	if !errors.Is(err, errWatcherTestInvalidPort) {
+		t.Fatalf("expected errWatcherTestInvalidPort, got: %v", err)
+	}
+}
+
+func writeWatcherTestFile(t *testing.T, filePath string, content string, modTime time.Time) {
+	t.Helper()
+
+	err := os.WriteFile(filePath, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Chtimes(filePath, modTime, modTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
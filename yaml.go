@@ -15,9 +15,11 @@
 package goutils
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 
 	"go.yaml.in/yaml/v4"
 )
@@ -43,6 +45,8 @@ const (
 	YAMLBinaryTag = "!!binary"
 	// YAMLMergeTag represents a constant for a YAML merge tag.
 	YAMLMergeTag = "!!merge"
+	// YAMLIncludeTag represents a constant for a custom YAML tag that includes another file.
+	YAMLIncludeTag = "!include"
 )
 
 // GetStringValueFromYAMLMap gets the string value from a YAML map node.
@@ -108,7 +112,13 @@ func GetNodeValueFromYAMLMap(node *yaml.Node, key string) (*yaml.Node, error) {
 }
 
 // LoadMultiYAMLDocumentStream loads multi-documents YAML from a reader stream.
-func LoadMultiYAMLDocumentStream[T any](reader io.Reader) ([]T, error) {
+// Options such as [DownloadFileWithStrict] and [DownloadFileWithEnvExpansion] apply to every document.
+// Decode errors with options are returned as a [ConfigDecodeError] with the document location.
+func LoadMultiYAMLDocumentStream[T any](reader io.Reader, options ...DownloadFileOption) ([]T, error) {
+	if len(options) > 0 {
+		return decodeDocuments[T](context.Background(), reader, "", ".yaml", newDownloadFileOptions(options), -1)
+	}
+
 	var results []T
 
 	loader, err := yaml.NewLoader(reader)
@@ -137,3 +147,20 @@ func LoadMultiYAMLDocumentStream[T any](reader io.Reader) ([]T, error) {
 
 	return results, nil
 }
+
+// LoadMultiYAMLDocumentStreamSeq returns an iterator that decodes multi-document YAML from a reader stream
+// one document at a time, so large streams aren't loaded into memory at once.
+// The iterator stops after the first error, when the context is canceled, or when the loop breaks.
+func LoadMultiYAMLDocumentStreamSeq[T any](
+	ctx context.Context,
+	reader io.Reader,
+	options ...DownloadFileOption,
+) iter.Seq2[T, error] {
+	return decodeDocumentSeq[T](ctx, reader, "", ".yaml", newDownloadFileOptions(options))
+}
+
+// WriteMultiYAMLDocumentStream encodes multi-document YAML separated by "---" to a writer stream,
+// so the output can be loaded by [LoadMultiYAMLDocumentStream].
+func WriteMultiYAMLDocumentStream[T any](writer io.Writer, documents []T) error {
+	return encodeYAMLDocuments(writer, documents, defaultWriteFileIndent)
+}